@@ -2,12 +2,14 @@ package main
 
 import (
 	_ "CookFinder.Backend/docs"
+	"CookFinder.Backend/internal/auth"
 	"CookFinder.Backend/internal/handler"
 	repository "CookFinder.Backend/internal/repo"
 	"CookFinder.Backend/internal/service"
 	"CookFinder.Backend/internal/storage"
 	"CookFinder.Backend/migrations"
 	"CookFinder.Backend/pkg/db"
+	"context"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
@@ -18,8 +20,13 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
 )
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token from /auth/login in the form "Bearer <token>"
 func main() {
 	slog.Info("Starting CookFinder Backend")
 	dbURL := os.Getenv("DATABASE_PUBLIC_URL")
@@ -39,24 +46,63 @@ func main() {
 
 	ingRepo := repository.NewIngredientRepository(DB)
 	catRepo := repository.NewCategoryRepository(DB)
+	tagRepo := repository.NewTagRepository(DB)
 	recipeRepo := repository.NewRecipeRepository(DB)
 	fileRepo := repository.NewFileRepository(DB)
 	recipeIngredientRepo := repository.NewRecipeIngredientRepository(DB)
+	userRepo := repository.NewUserRepository(DB)
+	collectionRepo := repository.NewCollectionRepository(DB)
+	reviewRepo := repository.NewReviewRepository(DB)
+	unitRepo := repository.NewUnitRepository(DB)
+	recipeStepRepo := repository.NewRecipeStepRepository(DB)
+	shoppingListRepo := repository.NewShoppingListRepository(DB)
+	mealPlanRepo := repository.NewMealPlanRepository(DB)
+	substitutionRepo := repository.NewSubstitutionRepository(DB)
+	pantryRepo := repository.NewPantryRepository(DB)
 
-	yStorage, err := storage.NewYandexStorage(
-		os.Getenv("YANDEX_ENDPOINT"),
-		os.Getenv("YANDEX_ACCESS_KEY"),
-		os.Getenv("YANDEX_SECRET_KEY"),
-		os.Getenv("YANDEX_BUCKET"),
-	)
+	// STORAGE_BACKEND: yandex (по умолчанию), s3 (в том числе MinIO), local или memory
+	objectStore, err := storage.New(storageConfig())
 	if err != nil {
-		slog.Info("Failed to create Yandex Storage client", "error", err)
+		// как и раньше, сервис поднимается без облака; файлы пишутся на диск и раздаются через /static
+		slog.Error("Failed to create object storage, falling back to local disk", "error", err)
+		if objectStore, err = storage.NewLocalStorage(storage.LocalDir, ""); err != nil {
+			log.Fatalf("failed to create local storage: %v", err)
+		}
 	}
 
-	ingService := service.NewIngredientService(ingRepo)
-	catService := service.NewCategoryService(catRepo)
-	recipeService := service.NewRecipeService(recipeRepo, recipeIngredientRepo)
-	fileService := service.NewFileService(fileRepo)
+	ingService := service.NewIngredientService(ingRepo, fileRepo)
+	catService := service.NewCategoryService(catRepo, fileRepo)
+	tagService := service.NewTagService(tagRepo)
+	recipeService := service.NewRecipeService(recipeRepo, recipeIngredientRepo, ingRepo, unitRepo, recipeStepRepo, fileRepo, substitutionRepo, pantryRepo)
+	fileService := service.NewFileService(fileRepo, objectStore)
+	collectionService := service.NewCollectionService(collectionRepo, recipeRepo)
+	reviewService := service.NewReviewService(reviewRepo)
+	unitService := service.NewUnitService(unitRepo, ingRepo)
+	shoppingListService := service.NewShoppingListService(shoppingListRepo, recipeRepo, recipeIngredientRepo, ingRepo, unitRepo)
+	mealPlanService := service.NewMealPlanService(mealPlanRepo, recipeRepo, shoppingListService)
+	substitutionService := service.NewSubstitutionService(substitutionRepo, recipeRepo)
+	pantryService := service.NewPantryService(pantryRepo, recipeRepo, ingRepo, unitRepo)
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		log.Fatalf("JWT_SECRET is not set")
+	}
+	tokens := auth.NewTokenManager(jwtSecret, durationEnv("JWT_ACCESS_TTL", 15*time.Minute), durationEnv("JWT_REFRESH_TTL", 30*24*time.Hour))
+	authService := service.NewAuthService(userRepo, tokens)
+
+	if email, password := os.Getenv("ADMIN_EMAIL"), os.Getenv("ADMIN_PASSWORD"); email != "" && password != "" {
+		if err := authService.EnsureAdmin(context.Background(), email, password); err != nil {
+			slog.Error("failed to ensure admin user", "error", err)
+		}
+	}
+	authMiddleware := handler.NewAuthMiddleware(tokens)
+
+	// Фоновая уборка по умолчанию выключена: включается FILE_SWEEP_INTERVAL > 0 (например, 24h).
+	// Включённая уборка только пишет отчёт в лог, пока не задан FILE_SWEEP_DRY_RUN=false.
+	if interval := durationEnv("FILE_SWEEP_INTERVAL", 0); interval > 0 {
+		dryRun := os.Getenv("FILE_SWEEP_DRY_RUN") != "false"
+		go fileService.RunSweeper(context.Background(), interval, durationEnv("FILE_SWEEP_GRACE", 72*time.Hour), dryRun)
+	}
 
 	r := gin.Default()
 
@@ -68,13 +114,24 @@ func main() {
 		AllowCredentials: true,
 	}))
 
+	r.Use(authMiddleware.Authenticate())
+
 	// Swagger docs
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	handler.NewIngredientHandler(r, ingService)
-	handler.NewCategoryHandler(r, catService)
-	handler.NewRecipeHandler(r, recipeService)
-	handler.NewFileHandler(r, fileService, yStorage)
+	handler.NewAuthHandler(r, authService, authMiddleware)
+	handler.NewIngredientHandler(r, ingService, authMiddleware)
+	handler.NewCategoryHandler(r, catService, authMiddleware)
+	handler.NewTagHandler(r, tagService, authMiddleware)
+	handler.NewRecipeHandler(r, recipeService, authMiddleware)
+	handler.NewFileHandler(r, fileService, authMiddleware)
+	handler.NewCollectionHandler(r, collectionService, authMiddleware)
+	handler.NewReviewHandler(r, reviewService, authMiddleware)
+	handler.NewUnitHandler(r, unitService)
+	handler.NewShoppingListHandler(r, shoppingListService, authMiddleware)
+	handler.NewMealPlanHandler(r, mealPlanService, authMiddleware)
+	handler.NewSubstitutionHandler(r, substitutionService, authMiddleware)
+	handler.NewPantryHandler(r, pantryService, authMiddleware)
 
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -84,3 +141,40 @@ func main() {
 
 	r.Run(":8080")
 }
+
+// storageConfig читает настройки хранилища; для yandex сохранены прежние переменные YANDEX_*
+func storageConfig() storage.Config {
+	cfg := storage.Config{
+		Backend:   os.Getenv("STORAGE_BACKEND"),
+		Endpoint:  os.Getenv("STORAGE_ENDPOINT"),
+		Region:    os.Getenv("STORAGE_REGION"),
+		AccessKey: os.Getenv("STORAGE_ACCESS_KEY"),
+		SecretKey: os.Getenv("STORAGE_SECRET_KEY"),
+		Bucket:    os.Getenv("STORAGE_BUCKET"),
+		PublicURL: os.Getenv("STORAGE_PUBLIC_URL"),
+	}
+	if cfg.Backend == "" || cfg.Backend == storage.BackendYandex {
+		cfg.Endpoint = os.Getenv("YANDEX_ENDPOINT")
+		cfg.AccessKey = os.Getenv("YANDEX_ACCESS_KEY")
+		cfg.SecretKey = os.Getenv("YANDEX_SECRET_KEY")
+		cfg.Bucket = os.Getenv("YANDEX_BUCKET")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	return cfg
+}
+
+func durationEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		slog.Error("invalid duration, using default", "key", key, "value", value, "default", def)
+		return def
+	}
+	return d
+}
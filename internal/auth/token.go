@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	TokenAccess  = "access"
+	TokenRefresh = "refresh"
+
+	issuer = "cook-finder"
+)
+
+type Claims struct {
+	Role string `json:"role"`
+	Type string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	AccessExpiresAt  time.Time
+	RefreshExpiresAt time.Time
+}
+
+// TokenManager выпускает и проверяет JWT (HS256). Access-токен короткоживущий,
+// refresh-токен нужен только для получения новой пары.
+type TokenManager struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewTokenManager(secret string, accessTTL, refreshTTL time.Duration) *TokenManager {
+	return &TokenManager{
+		secret:     []byte(secret),
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}
+}
+
+func (it *TokenManager) Issue(user *model.User) (*TokenPair, error) {
+	now := time.Now()
+
+	access, accessExp, err := it.sign(user, TokenAccess, now, it.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, refreshExp, err := it.sign(user, TokenRefresh, now, it.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:      access,
+		RefreshToken:     refresh,
+		AccessExpiresAt:  accessExp,
+		RefreshExpiresAt: refreshExp,
+	}, nil
+}
+
+// Parse проверяет подпись, срок действия и тип токена
+func (it *TokenManager) Parse(token, tokenType string) (model.Principal, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		return it.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return model.Principal{}, puberr.ErrInvalidToken.SetCause(err)
+	}
+
+	if claims.Type != tokenType || claims.Subject == "" {
+		return model.Principal{}, puberr.ErrInvalidToken.SetCause(errors.New("unexpected token type"))
+	}
+
+	return model.Principal{UserID: claims.Subject, Role: claims.Role}, nil
+}
+
+func (it *TokenManager) sign(user *model.User, tokenType string, now time.Time, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := now.Add(ttl)
+
+	claims := Claims{
+		Role: user.Role,
+		Type: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   user.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(it.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/service"
+	"CookFinder.Backend/pkg/dto"
+	"CookFinder.Backend/pkg/puberr"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+func NewAuthHandler(r *gin.Engine, svc *service.AuthService, mw *AuthMiddleware) {
+	h := &AuthHandler{service: svc}
+	routes := r.Group("/auth")
+	{
+		routes.POST("register", h.Register)
+		routes.POST("login", h.Login)
+		routes.POST("refresh", h.Refresh)
+		routes.GET("me", mw.RequireRoles(), h.Me)
+	}
+
+	r.PUT("/users/:id/role", mw.RequireRoles(model.RoleAdmin), h.SetRole)
+}
+
+// Register godoc
+// @Summary Register a new user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param credentials body dto.CredentialsRequest true "Email and password"
+// @Success 201 {object} dto.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var input dto.CredentialsRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.service.Register(c.Request.Context(), input.Email, input.Password)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewUserFromModel(user))
+}
+
+// Login godoc
+// @Summary Log in and get a token pair
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param credentials body dto.CredentialsRequest true "Email and password"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var input dto.CredentialsRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pair, err := h.service.Login(c.Request.Context(), input.Email, input.Password)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTokenResponse(pair))
+}
+
+// Refresh godoc
+// @Summary Exchange a refresh token for a new token pair
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token body dto.RefreshRequest true "Refresh token"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var input dto.RefreshRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pair, err := h.service.Refresh(c.Request.Context(), input.RefreshToken)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTokenResponse(pair))
+}
+
+// Me godoc
+// @Summary Get the current user
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponse
+// @Failure 401 {object} map[string]string
+// @Router /auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	p, _ := principal(c)
+
+	user, err := h.service.GetUser(c.Request.Context(), p.UserID)
+	if err != nil {
+		writeError(c, puberr.ErrNotAuthorized.SetCause(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewUserFromModel(user))
+}
+
+// SetRole godoc
+// @Summary Change user role
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param role body dto.RoleRequest true "New role"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /users/{id}/role [put]
+func (h *AuthHandler) SetRole(c *gin.Context) {
+	var input dto.RoleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.service.SetRole(c.Request.Context(), c.Param("id"), input.Role)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewUserFromModel(user))
+}
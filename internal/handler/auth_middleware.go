@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/auth"
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const principalKey = "principal"
+
+type AuthMiddleware struct {
+	tokens *auth.TokenManager
+}
+
+func NewAuthMiddleware(tokens *auth.TokenManager) *AuthMiddleware {
+	return &AuthMiddleware{tokens: tokens}
+}
+
+// Authenticate разбирает Bearer-токен, если он передан, и кладёт пользователя в контекст.
+// Запросы без токена проходят дальше анонимно: чтение каталога остаётся публичным.
+func (it *AuthMiddleware) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			writeError(c, puberr.ErrInvalidToken)
+			return
+		}
+
+		principal, err := it.tokens.Parse(strings.TrimSpace(token), auth.TokenAccess)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+// RequireRoles пропускает только аутентифицированных пользователей с одной из ролей;
+// без ролей достаточно любого аутентифицированного пользователя
+func (it *AuthMiddleware) RequireRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := principal(c)
+		if !ok {
+			writeError(c, puberr.ErrNotAuthorized)
+			return
+		}
+
+		if len(roles) > 0 && !p.HasRole(roles...) {
+			writeError(c, puberr.ErrForbidden)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireEditor — права на изменение каталога
+func (it *AuthMiddleware) RequireEditor() gin.HandlerFunc {
+	return it.RequireRoles(model.RoleAdmin, model.RoleEditor)
+}
+
+func principal(c *gin.Context) (model.Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return model.Principal{}, false
+	}
+	p, ok := value.(model.Principal)
+	return p, ok
+}
@@ -13,15 +13,15 @@ type CategoryHandler struct {
 	service *service.CategoryService
 }
 
-func NewCategoryHandler(r *gin.Engine, svc *service.CategoryService) {
+func NewCategoryHandler(r *gin.Engine, svc *service.CategoryService, auth *AuthMiddleware) {
 	h := &CategoryHandler{service: svc}
 	routes := r.Group("/categories")
 	{
 		routes.GET("", h.GetAll)
 		routes.GET(":id", h.GetByID)
-		routes.POST("", h.Create)
-		routes.DELETE(":id", h.Delete)
-		routes.PUT(":id", h.Update)
+		routes.POST("", auth.RequireEditor(), h.Create)
+		routes.DELETE(":id", auth.RequireEditor(), h.Delete)
+		routes.PUT(":id", auth.RequireEditor(), h.Update)
 	}
 }
 
@@ -29,23 +29,28 @@ func NewCategoryHandler(r *gin.Engine, svc *service.CategoryService) {
 // @Summary GetAll all categories
 // @Tags Categories
 // @Produce json
-// @Success 200 {array} dto.Category
+// @Param limit query int false "Page size" default(20) maximum(100)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param sort query string false "Sort field" Enums(created_at, name) default(name)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Success 200 {object} dto.Page{items=[]dto.Category}
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /categories [get]
 func (h *CategoryHandler) GetAll(c *gin.Context) {
-	categories, err := h.service.GetAll(c.Request.Context())
+	page, err := parsePage(c, "name", false)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	results := make([]dto.Category, 0, len(categories))
-
-	for _, c := range categories {
-		results = append(results, *dto.NewCategoryFromModel(&c))
+	categories, err := h.service.GetAll(c.Request.Context(), page)
+	if err != nil {
+		writeError(c, err)
+		return
 	}
 
-	c.JSON(http.StatusOK, results)
+	c.JSON(http.StatusOK, dto.NewPageFromModel(categories, dto.NewCategoryFromModel))
 }
 
 // GetByID godoc
@@ -71,12 +76,15 @@ func (h *CategoryHandler) GetByID(c *gin.Context) {
 // Create godoc
 // @Summary Create a new category
 // @Tags Categories
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param category body dto.Category true "Category body"
 // @Success 201 {object} dto.Category
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /categories [post]
 func (h *CategoryHandler) Create(c *gin.Context) {
 	var input dto.Category
@@ -86,27 +94,31 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 	}
 
 	rc := &model.Category{
-		Name:     input.Name,
-		ImageUrl: input.ImageURL,
+		Name:        input.Name,
+		ImageUrl:    input.ImageURL,
+		ImageFileID: input.ImageFileID,
 	}
 
 	if err := h.service.Create(c.Request.Context(), rc); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, input)
+	c.JSON(http.StatusCreated, dto.NewCategoryFromModel(rc))
 }
 
 // Update godoc
-// @Summary Update a new category
+// @Summary Update category by ID
 // @Tags Categories
+// @Security BearerAuth
 // @Accept json
-// @Produce json
+// @Param id path string true "Category ID"
 // @Param category body dto.Category true "Category body"
-// @Success 201 {object} dto.Category
+// @Success 204 {string} string "No Content"
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /categories [post]
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /categories/{id} [put]
 func (h *CategoryHandler) Update(c *gin.Context) {
 	id := c.Param("id")
 
@@ -117,13 +129,14 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 	}
 
 	rc := &model.Category{
-		ID:       id,
-		Name:     input.Name,
-		ImageUrl: input.ImageURL,
+		ID:          id,
+		Name:        input.Name,
+		ImageUrl:    input.ImageURL,
+		ImageFileID: input.ImageFileID,
 	}
 
 	if err := h.service.Update(c.Request.Context(), rc); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -132,11 +145,15 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 
 // Delete godoc
 // @Summary Delete category by ID
+// @Description Recipes are kept: those with this primary category move to their next category, or to none.
 // @Tags Categories
+// @Security BearerAuth
 // @Produce json
 // @Param id path string true "Category ID"
 // @Success 204 {string} string "No Content"
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /categories/{id} [delete]
 func (h *CategoryHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
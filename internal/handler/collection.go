@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/service"
+	"CookFinder.Backend/pkg/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CollectionHandler struct {
+	service *service.CollectionService
+}
+
+func NewCollectionHandler(r *gin.Engine, svc *service.CollectionService, auth *AuthMiddleware) {
+	h := &CollectionHandler{service: svc}
+	routes := r.Group("/collections", auth.RequireRoles())
+	{
+		routes.GET("", h.GetAll)
+		routes.POST("", h.Create)
+		routes.PUT(":id", h.Rename)
+		routes.DELETE(":id", h.Delete)
+		routes.GET(":id/recipes", h.GetRecipes)
+		routes.POST(":id/recipes", h.AddRecipe)
+		routes.PUT(":id/recipes/order", h.Reorder)
+		routes.DELETE(":id/recipes/:recipe_id", h.RemoveRecipe)
+	}
+}
+
+// GetAll godoc
+// @Summary Get collections of the current user
+// @Tags Collections
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} dto.CollectionResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /collections [get]
+func (h *CollectionHandler) GetAll(c *gin.Context) {
+	p, _ := principal(c)
+
+	collections, err := h.service.GetAll(c.Request.Context(), p.UserID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	results := make([]dto.CollectionResponse, 0, len(collections))
+	for _, col := range collections {
+		results = append(results, *dto.NewCollectionFromModel(&col))
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// Create godoc
+// @Summary Create a collection
+// @Tags Collections
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param collection body dto.CollectionRequest true "Collection name"
+// @Success 201 {object} dto.CollectionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /collections [post]
+func (h *CollectionHandler) Create(c *gin.Context) {
+	var input dto.CollectionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p, _ := principal(c)
+	collection, err := h.service.Create(c.Request.Context(), p.UserID, input.Name)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewCollectionFromModel(collection))
+}
+
+// Rename godoc
+// @Summary Rename a collection
+// @Tags Collections
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Param collection body dto.CollectionRequest true "Collection name"
+// @Success 200 {object} dto.CollectionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /collections/{id} [put]
+func (h *CollectionHandler) Rename(c *gin.Context) {
+	var input dto.CollectionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p, _ := principal(c)
+	collection, err := h.service.Rename(c.Request.Context(), p.UserID, c.Param("id"), input.Name)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewCollectionFromModel(collection))
+}
+
+// Delete godoc
+// @Summary Delete a collection
+// @Tags Collections
+// @Security BearerAuth
+// @Param id path string true "Collection ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /collections/{id} [delete]
+func (h *CollectionHandler) Delete(c *gin.Context) {
+	p, _ := principal(c)
+	if err := h.service.Delete(c.Request.Context(), p.UserID, c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetRecipes godoc
+// @Summary Get recipes of a collection in saved order
+// @Tags Collections
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {array} dto.RecipeResponse
+// @Failure 404 {object} map[string]string
+// @Router /collections/{id}/recipes [get]
+func (h *CollectionHandler) GetRecipes(c *gin.Context) {
+	p, _ := principal(c)
+
+	recipes, err := h.service.GetRecipes(c.Request.Context(), p.UserID, c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	results := make([]dto.RecipeResponse, 0, len(recipes))
+	for _, r := range recipes {
+		results = append(results, *dto.NewRecipeResponseFromModel(&r))
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// AddRecipe godoc
+// @Summary Add a recipe to the end of a collection
+// @Tags Collections
+// @Security BearerAuth
+// @Accept json
+// @Param id path string true "Collection ID"
+// @Param recipe body dto.CollectionRecipeRequest true "Recipe"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /collections/{id}/recipes [post]
+func (h *CollectionHandler) AddRecipe(c *gin.Context) {
+	var input dto.CollectionRecipeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p, _ := principal(c)
+	if err := h.service.AddRecipe(c.Request.Context(), p.UserID, c.Param("id"), input.RecipeID); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveRecipe godoc
+// @Summary Remove a recipe from a collection
+// @Tags Collections
+// @Security BearerAuth
+// @Param id path string true "Collection ID"
+// @Param recipe_id path string true "Recipe ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /collections/{id}/recipes/{recipe_id} [delete]
+func (h *CollectionHandler) RemoveRecipe(c *gin.Context) {
+	p, _ := principal(c)
+	if err := h.service.RemoveRecipe(c.Request.Context(), p.UserID, c.Param("id"), c.Param("recipe_id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Reorder godoc
+// @Summary Reorder recipes in a collection
+// @Tags Collections
+// @Security BearerAuth
+// @Accept json
+// @Param id path string true "Collection ID"
+// @Param order body dto.CollectionOrderRequest true "All recipe IDs of the collection in the new order"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /collections/{id}/recipes/order [put]
+func (h *CollectionHandler) Reorder(c *gin.Context) {
+	var input dto.CollectionOrderRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p, _ := principal(c)
+	if err := h.service.Reorder(c.Request.Context(), p.UserID, c.Param("id"), input.RecipeIDs); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
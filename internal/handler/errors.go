@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"CookFinder.Backend/pkg/puberr"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeError отдаёт клиенту публичную часть ошибки, а приватную пишет в лог
+func writeError(c *gin.Context, err error) {
+	pubErr, err := puberr.ErrToPubErr(err)
+	if err != nil {
+		slog.Error(
+			"Private error",
+			"err", err,
+			"path", c.Request.URL.Path,
+			"method", c.Request.Method,
+			"query", c.Request.URL.Query(),
+		)
+	}
+
+	c.AbortWithStatusJSON(pubErr.HTTPCode, pubErr)
+}
@@ -1,52 +1,77 @@
 package handler
 
 import (
+	"CookFinder.Backend/internal/imaging"
 	"CookFinder.Backend/internal/model"
 	"CookFinder.Backend/internal/service"
 	"CookFinder.Backend/internal/storage"
-	"CookFinder.Backend/pkg/uuid"
+	"CookFinder.Backend/pkg/dto"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type FileHandler struct {
 	fileService *service.FileService
-	storage     *storage.YandexStorage
 }
 
 func NewFileHandler(
 	r *gin.Engine,
 	fileService *service.FileService,
-	storage *storage.YandexStorage,
+	auth *AuthMiddleware,
 ) {
 	h := &FileHandler{
 		fileService: fileService,
-		storage:     storage,
 	}
-	r.POST("/upload", h.Upload)
+	r.POST("/upload", auth.RequireEditor(), h.Upload)
+	r.POST("/files/presign", auth.RequireEditor(), h.Presign)
+	r.POST("/files/complete", auth.RequireEditor(), h.Complete)
 	r.GET("/files", h.GetAll)
-	r.DELETE("/files/:id", h.Delete)
-	r.Static("/static", "./uploads")
+	r.DELETE("/files/:id", auth.RequireEditor(), h.Delete)
+	r.POST("/files/sweep", auth.RequireRoles(model.RoleAdmin), h.Sweep)
+	r.Static("/static", storage.LocalDir)
 }
 
 // Upload godoc
 // @Summary Upload image file
+// @Description Accepts JPEG, PNG, WebP or HEIC up to 20 MB (checked by content, not extension).
+// @Description The image is rotated by EXIF orientation, stripped of metadata and stored as thumb, card and full renditions.
 // @Tags Files
+// @Security BearerAuth
 // @Accept multipart/form-data
 // @Produce json
 // @Param image formData file true "Image File"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} model.File
 // @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /upload [post]
 func (it *FileHandler) Upload(c *gin.Context) {
+	// запас на заголовки multipart сверх размера самого файла
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, imaging.MaxFileSize+1<<20)
+
 	fileHeader, err := c.FormFile("image")
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(c, imaging.ErrTooLarge)
+			return
+		}
 		slog.Error("failed to get uploaded file", "error", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "no file provided"})
 		return
 	}
+	if fileHeader.Size > imaging.MaxFileSize {
+		writeError(c, imaging.ErrTooLarge)
+		return
+	}
 
 	file, err := fileHeader.Open()
 	if err != nil {
@@ -56,72 +81,166 @@ func (it *FileHandler) Upload(c *gin.Context) {
 	}
 	defer file.Close()
 
-	url, err := it.storage.UploadFile(c, file, fileHeader)
+	data, err := io.ReadAll(io.LimitReader(file, imaging.MaxFileSize+1))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "upload failed"})
+		slog.Error("failed to read uploaded file", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read file"})
 		return
 	}
 
-	f := &model.File{
-		ID:   uuid.V7().String(),
-		Name: fileHeader.Filename,
-		Path: url,
+	f, err := it.fileService.Upload(c.Request.Context(), fileHeader.Filename, data)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, f)
+}
+
+// Presign godoc
+// @Summary Get a presigned URL for a direct-to-bucket upload
+// @Description Upload the original with PUT to upload_url, sending the returned Content-Type, then call POST /files/complete with the key.
+// @Tags Files
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param upload body dto.PresignUploadRequest true "File name, content type and size"
+// @Success 200 {object} dto.PresignUploadResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 501 {object} map[string]string
+// @Router /files/presign [post]
+func (it *FileHandler) Presign(c *gin.Context) {
+	var input dto.PresignUploadRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	err = it.fileService.CreateFile(c, f)
+	upload, err := it.fileService.Presign(c.Request.Context(), input.FileName, input.ContentType, input.Size)
 	if err != nil {
-		slog.Error("failed to save file metadata", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "upload failed"})
+		writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"path": url})
+	c.JSON(http.StatusOK, dto.NewPresignUploadFromModel(upload))
+}
+
+// Complete godoc
+// @Summary Finish a direct-to-bucket upload
+// @Description Checks the uploaded object's size and content type, then processes it like /upload. The original is removed from the bucket.
+// @Tags Files
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param upload body dto.CompleteUploadRequest true "Key returned by /files/presign"
+// @Success 201 {object} model.File
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Router /files/complete [post]
+func (it *FileHandler) Complete(c *gin.Context) {
+	var input dto.CompleteUploadRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	f, err := it.fileService.Complete(c.Request.Context(), input.Key)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, f)
 }
 
 // GetAll godoc
 // @Summary GetAll files
 // @Tags Files
 // @Produce json
-// @Success 204 {string} string "No Content"
+// @Param limit query int false "Page size" default(20) maximum(100)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param sort query string false "Sort field" Enums(created_at, name) default(name)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Success 200 {object} dto.Page{items=[]model.File}
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /files [get]
 func (it *FileHandler) GetAll(c *gin.Context) {
-	files, err := it.fileService.GetAllFiles(c)
+	page, err := parsePage(c, "name", false)
 	if err != nil {
-		slog.Error("failed to get files", "error", err)
+		writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"files": files})
+	files, err := it.fileService.GetAllFiles(c, page)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageFromModel(files, func(f *model.File) *model.File { return f }))
 }
 
 // Delete godoc
 // @Summary Delete by id
 // @Tags Files
+// @Security BearerAuth
 // @Param id path string true "File id"
 // @Success 204
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /files/{id} [delete]
 func (it *FileHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
+	if err := it.fileService.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
 
-	f, err := it.fileService.GetFileByID(c, id)
+	c.Status(http.StatusNoContent)
+}
+
+// Sweep godoc
+// @Summary Find or remove unused files
+// @Description Reports files no recipe, step, ingredient or category references, and bucket objects without a files row, older than grace.
+// @Description Nothing is deleted unless dry_run=false. Grace below 1h is raised to 1h.
+// @Tags Files
+// @Security BearerAuth
+// @Produce json
+// @Param dry_run query bool false "Only report, do not delete" default(true)
+// @Param grace query string false "Minimum age, Go duration" default(72h)
+// @Success 200 {object} dto.SweepReportResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /files/sweep [post]
+func (it *FileHandler) Sweep(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "true"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid dry_run"})
 		return
 	}
-
-	if err := it.storage.DeleteFile(c.Request.Context(), f.Name); err != nil {
-		slog.Error("failed to delete file from storage", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete from storage"})
+	grace, err := time.ParseDuration(c.DefaultQuery("grace", "72h"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid grace"})
 		return
 	}
 
-	if err := it.fileService.DeleteFile(c, id); err != nil {
-		slog.Error("failed to delete file metadata", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete metadata"})
+	report, err := it.fileService.Sweep(c.Request.Context(), grace, dryRun)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, dto.NewSweepReportFromModel(report))
 }
@@ -4,6 +4,7 @@ import (
 	"CookFinder.Backend/internal/model"
 	"CookFinder.Backend/internal/service"
 	"CookFinder.Backend/pkg/dto"
+	"CookFinder.Backend/pkg/rest"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -13,15 +14,16 @@ type IngredientHandler struct {
 	service *service.IngredientService
 }
 
-func NewIngredientHandler(r *gin.Engine, svc *service.IngredientService) {
+func NewIngredientHandler(r *gin.Engine, svc *service.IngredientService, auth *AuthMiddleware) {
 	h := &IngredientHandler{service: svc}
 	routes := r.Group("/ingredients")
 	{
 		routes.GET("", h.GetAll)
+		routes.GET("suggest", h.Suggest)
 		routes.GET(":id", h.GetByID)
-		routes.POST("", h.Create)
-		routes.PUT(":id", h.Update)
-		routes.DELETE(":id", h.Delete)
+		routes.POST("", auth.RequireEditor(), h.Create)
+		routes.PUT(":id", auth.RequireEditor(), h.Update)
+		routes.DELETE(":id", auth.RequireEditor(), h.Delete)
 	}
 }
 
@@ -29,19 +31,61 @@ func NewIngredientHandler(r *gin.Engine, svc *service.IngredientService) {
 // @Summary Get all ingredients
 // @Tags IngredientIDs
 // @Produce json
-// @Success 200 {array} dto.IngredientResponse
+// @Param limit query int false "Page size" default(20) maximum(100)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param sort query string false "Sort field" Enums(created_at, name) default(name)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Success 200 {object} dto.Page{items=[]dto.IngredientResponse}
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /ingredients [get]
 func (h *IngredientHandler) GetAll(c *gin.Context) {
-	ingredients, err := h.service.GetAll(c.Request.Context())
+	page, err := parsePage(c, "name", false)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
+		return
+	}
+
+	ingredients, err := h.service.GetAll(c.Request.Context(), page)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageFromModel(ingredients, dto.NewIngredientFromModel))
+}
+
+// Suggest godoc
+// @Summary Suggest ingredients by name
+// @Description Typo-tolerant trigram search; prefix matches come first for autocomplete
+// @Tags IngredientIDs
+// @Produce json
+// @Param q query string true "Text typed by the user"
+// @Param limit query int false "Max number of suggestions" default(10) maximum(20)
+// @Success 200 {array} dto.IngredientSuggestionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /ingredients/suggest [get]
+func (h *IngredientHandler) Suggest(c *gin.Context) {
+	limit := 10
+	if v := c.Query("limit"); v != "" {
+		parsed, err := rest.ParseIntParam(v)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = parsed
+	}
+
+	suggestions, err := h.service.Suggest(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
-	results := make([]dto.IngredientResponse, 0, len(ingredients))
-	for _, ing := range ingredients {
-		results = append(results, *dto.NewIngredientFromModel(&ing))
+	results := make([]dto.IngredientSuggestionResponse, 0, len(suggestions))
+	for _, s := range suggestions {
+		results = append(results, *dto.NewIngredientSuggestionFromModel(&s))
 	}
 
 	c.JSON(http.StatusOK, results)
@@ -68,12 +112,15 @@ func (h *IngredientHandler) GetByID(c *gin.Context) {
 // Create godoc
 // @Summary Create a new ingredient
 // @Tags IngredientIDs
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param ingredient body dto.IngredientRequest true "Ingredient body"
 // @Success 201 {object} dto.IngredientResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /ingredients [post]
 func (h *IngredientHandler) Create(c *gin.Context) {
 	var input dto.IngredientRequest
@@ -83,12 +130,21 @@ func (h *IngredientHandler) Create(c *gin.Context) {
 	}
 
 	ingredient := &model.Ingredient{
-		Name:     input.Name,
-		ImageUrl: input.ImageUrl,
+		Name:              input.Name,
+		ImageUrl:          input.ImageUrl,
+		ImageFileID:       input.ImageFileID,
+		DensityGPerMl:     input.DensityGPerMl,
+		UnitWeightG:       input.UnitWeightG,
+		Nutrients:         model.Nutrients(input.Nutrients),
+		Allergens:         input.Allergens,
+		IsAnimal:          input.IsAnimal,
+		IsMeat:            input.IsMeat,
+		AllergensReviewed: input.AllergensReviewed,
+		Aisle:             input.Aisle,
 	}
 
 	if err := h.service.Create(c.Request.Context(), ingredient); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -98,6 +154,7 @@ func (h *IngredientHandler) Create(c *gin.Context) {
 // Update godoc
 // @Summary Update ingredient by ID
 // @Tags IngredientIDs
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "Ingredient ID"
@@ -106,6 +163,8 @@ func (h *IngredientHandler) Create(c *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /ingredients/{id} [put]
 func (h *IngredientHandler) Update(c *gin.Context) {
 	id := c.Param("id")
@@ -117,13 +176,22 @@ func (h *IngredientHandler) Update(c *gin.Context) {
 	}
 
 	ingredient := &model.Ingredient{
-		ID:       id,
-		Name:     input.Name,
-		ImageUrl: input.ImageUrl,
+		ID:                id,
+		Name:              input.Name,
+		ImageUrl:          input.ImageUrl,
+		ImageFileID:       input.ImageFileID,
+		DensityGPerMl:     input.DensityGPerMl,
+		UnitWeightG:       input.UnitWeightG,
+		Nutrients:         model.Nutrients(input.Nutrients),
+		Allergens:         input.Allergens,
+		IsAnimal:          input.IsAnimal,
+		IsMeat:            input.IsMeat,
+		AllergensReviewed: input.AllergensReviewed,
+		Aisle:             input.Aisle,
 	}
 
 	if err := h.service.Update(c.Request.Context(), ingredient); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -133,9 +201,12 @@ func (h *IngredientHandler) Update(c *gin.Context) {
 // Delete godoc
 // @Summary Delete ingredient by ID
 // @Tags IngredientIDs
+// @Security BearerAuth
 // @Param id path string true "Ingredient ID"
 // @Success 204
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /ingredients/{id} [delete]
 func (h *IngredientHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/service"
+	"CookFinder.Backend/pkg/dto"
+	"CookFinder.Backend/pkg/puberr"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MealPlanHandler struct {
+	service *service.MealPlanService
+}
+
+func NewMealPlanHandler(r *gin.Engine, svc *service.MealPlanService, auth *AuthMiddleware) {
+	h := &MealPlanHandler{service: svc}
+	routes := r.Group("/meal-plan", auth.RequireRoles())
+	{
+		routes.GET("", h.GetRange)
+		routes.POST("", h.Create)
+		routes.PUT(":id", h.Update)
+		routes.DELETE(":id", h.Delete)
+		routes.GET("week", h.Week)
+		routes.POST("week/shopping-list", h.WeekShoppingList)
+		routes.POST("generate", h.Generate)
+	}
+}
+
+// GetRange godoc
+// @Summary Get meal plan entries of the current user
+// @Tags MealPlan
+// @Security BearerAuth
+// @Produce json
+// @Param from query string true "First date, YYYY-MM-DD"
+// @Param to query string false "Last date, YYYY-MM-DD; defaults to from + 6 days"
+// @Success 200 {array} dto.MealPlanEntryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /meal-plan [get]
+func (h *MealPlanHandler) GetRange(c *gin.Context) {
+	from, err := queryDate(c, "from")
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	if from.IsZero() {
+		writeError(c, puberr.NewPubErr("from is required"))
+		return
+	}
+	to, err := queryDate(c, "to")
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, 6)
+	}
+
+	p, _ := principal(c)
+	entries, err := h.service.GetRange(c.Request.Context(), p.UserID, from, to)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	results := make([]dto.MealPlanEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		results = append(results, *dto.NewMealPlanEntryFromModel(&entry))
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// Week godoc
+// @Summary Get a week of the meal plan with nutrition totals per day
+// @Tags MealPlan
+// @Security BearerAuth
+// @Produce json
+// @Param start query string false "First day, YYYY-MM-DD; defaults to Monday of the current week"
+// @Success 200 {object} dto.MealPlanWeekResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /meal-plan/week [get]
+func (h *MealPlanHandler) Week(c *gin.Context) {
+	start, err := queryDate(c, "start")
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	p, _ := principal(c)
+	week, err := h.service.Week(c.Request.Context(), p.UserID, start)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMealPlanWeekFromModel(week))
+}
+
+// WeekShoppingList godoc
+// @Summary Build a shopping list for a week of the meal plan
+// @Tags MealPlan
+// @Security BearerAuth
+// @Produce json
+// @Param start query string false "First day, YYYY-MM-DD; defaults to Monday of the current week"
+// @Success 201 {object} dto.ShoppingListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /meal-plan/week/shopping-list [post]
+func (h *MealPlanHandler) WeekShoppingList(c *gin.Context) {
+	start, err := queryDate(c, "start")
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	p, _ := principal(c)
+	list, err := h.service.WeekShoppingList(c.Request.Context(), p.UserID, start)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewShoppingListFromModel(list))
+}
+
+// Generate godoc
+// @Summary Propose a 7-day meal plan under calorie and diet constraints
+// @Description Diet, allergens and excluded ingredients are strict filters; calories and macro split are matched as closely as the catalogue allows.
+// @Description A recipe is not repeated within no_repeat_days while other recipes fit, and recipes reusing ingredients of earlier meals are preferred.
+// @Description The same seed with the same catalogue gives the same plan. With save=true the plan replaces the calendar entries of the same slots in that week.
+// @Tags MealPlan
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param constraints body dto.MealPlanGenerateRequest true "Plan constraints"
+// @Success 200 {object} dto.GeneratedMealPlanResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /meal-plan/generate [post]
+func (h *MealPlanHandler) Generate(c *gin.Context) {
+	var input dto.MealPlanGenerateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var start time.Time
+	if input.Start != "" {
+		var err error
+		if start, err = time.Parse(time.DateOnly, input.Start); err != nil {
+			writeError(c, puberr.NewPubErr("start must be YYYY-MM-DD"))
+			return
+		}
+	}
+
+	p, _ := principal(c)
+	plan, err := h.service.Generate(c.Request.Context(), p.UserID, model.MealPlanConstraints{
+		Start:              start,
+		Slots:              input.Slots,
+		Servings:           input.Servings,
+		EnergyPerDay:       input.EnergyPerDay,
+		ProteinPercent:     input.ProteinPercent,
+		FatPercent:         input.FatPercent,
+		CarbsPercent:       input.CarbsPercent,
+		Diet:               input.Diet,
+		ExcludeAllergens:   input.ExcludeAllergens,
+		ExcludeIngredients: input.ExcludeIngredients,
+		NoRepeatDays:       input.NoRepeatDays,
+		Seed:               input.Seed,
+		Save:               input.Save,
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewGeneratedMealPlanFromModel(plan))
+}
+
+// Create godoc
+// @Summary Add a recipe to the meal plan
+// @Tags MealPlan
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param entry body dto.MealPlanEntryRequest true "Date, slot, recipe and servings"
+// @Success 201 {object} dto.MealPlanEntryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /meal-plan [post]
+func (h *MealPlanHandler) Create(c *gin.Context) {
+	entry, ok := bindMealPlanEntry(c)
+	if !ok {
+		return
+	}
+
+	created, err := h.service.Create(c.Request.Context(), entry)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewMealPlanEntryFromModel(created))
+}
+
+// Update godoc
+// @Summary Update a meal plan entry
+// @Tags MealPlan
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Entry ID"
+// @Param entry body dto.MealPlanEntryRequest true "Date, slot, recipe and servings"
+// @Success 200 {object} dto.MealPlanEntryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /meal-plan/{id} [put]
+func (h *MealPlanHandler) Update(c *gin.Context) {
+	entry, ok := bindMealPlanEntry(c)
+	if !ok {
+		return
+	}
+	entry.ID = c.Param("id")
+
+	updated, err := h.service.Update(c.Request.Context(), entry)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMealPlanEntryFromModel(updated))
+}
+
+// Delete godoc
+// @Summary Delete a meal plan entry
+// @Tags MealPlan
+// @Security BearerAuth
+// @Param id path string true "Entry ID"
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /meal-plan/{id} [delete]
+func (h *MealPlanHandler) Delete(c *gin.Context) {
+	p, _ := principal(c)
+	if err := h.service.Delete(c.Request.Context(), p.UserID, c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func bindMealPlanEntry(c *gin.Context) (*model.MealPlanEntry, bool) {
+	var input dto.MealPlanEntryRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	date, err := time.Parse(time.DateOnly, input.Date)
+	if err != nil {
+		writeError(c, puberr.NewPubErr("date must be YYYY-MM-DD"))
+		return nil, false
+	}
+
+	p, _ := principal(c)
+	return &model.MealPlanEntry{
+		UserID:   p.UserID,
+		Date:     date,
+		Slot:     input.Slot,
+		RecipeID: input.RecipeID,
+		Servings: input.Servings,
+	}, true
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/service"
+	"CookFinder.Backend/pkg/dto"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/rest"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PantryHandler struct {
+	service *service.PantryService
+}
+
+func NewPantryHandler(r *gin.Engine, svc *service.PantryService, auth *AuthMiddleware) {
+	h := &PantryHandler{service: svc}
+	routes := r.Group("/pantry", auth.RequireRoles())
+	{
+		routes.GET("", h.GetAll)
+		routes.POST("", h.Create)
+		routes.GET("expiring", h.Expiring)
+		routes.GET(":id", h.GetByID)
+		routes.PUT(":id", h.Update)
+		routes.DELETE(":id", h.Delete)
+	}
+	r.POST("/recipes/:id/cooked", auth.RequireRoles(), h.Cooked)
+}
+
+// GetAll godoc
+// @Summary Get pantry of the current user
+// @Description Items expiring sooner come first, items without an expiry date last.
+// @Tags Pantry
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} dto.PantryItemResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /pantry [get]
+func (h *PantryHandler) GetAll(c *gin.Context) {
+	p, _ := principal(c)
+	items, err := h.service.GetAll(c.Request.Context(), p.UserID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	results := make([]dto.PantryItemResponse, 0, len(items))
+	for _, item := range items {
+		results = append(results, *dto.NewPantryItemFromModel(&item))
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// GetByID godoc
+// @Summary Get pantry item by ID
+// @Tags Pantry
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Pantry item ID"
+// @Success 200 {object} dto.PantryItemResponse
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /pantry/{id} [get]
+func (h *PantryHandler) GetByID(c *gin.Context) {
+	p, _ := principal(c)
+	item, err := h.service.GetByID(c.Request.Context(), p.UserID, c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPantryItemFromModel(item))
+}
+
+// Create godoc
+// @Summary Add an item to the pantry
+// @Description Several items of the same ingredient are kept apart, e.g. two packs with different expiry dates.
+// @Tags Pantry
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param item body dto.PantryItemRequest true "Ingredient, quantity, unit and optional expiry date"
+// @Success 201 {object} dto.PantryItemResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /pantry [post]
+func (h *PantryHandler) Create(c *gin.Context) {
+	item, ok := bindPantryItem(c)
+	if !ok {
+		return
+	}
+
+	created, err := h.service.Create(c.Request.Context(), item)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewPantryItemFromModel(created))
+}
+
+// Update godoc
+// @Summary Update a pantry item
+// @Tags Pantry
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Pantry item ID"
+// @Param item body dto.PantryItemRequest true "Ingredient, quantity, unit and optional expiry date"
+// @Success 200 {object} dto.PantryItemResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /pantry/{id} [put]
+func (h *PantryHandler) Update(c *gin.Context) {
+	item, ok := bindPantryItem(c)
+	if !ok {
+		return
+	}
+	item.ID = c.Param("id")
+
+	updated, err := h.service.Update(c.Request.Context(), item)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPantryItemFromModel(updated))
+}
+
+// Delete godoc
+// @Summary Remove an item from the pantry
+// @Tags Pantry
+// @Security BearerAuth
+// @Param id path string true "Pantry item ID"
+// @Success 204 {string} string "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /pantry/{id} [delete]
+func (h *PantryHandler) Delete(c *gin.Context) {
+	p, _ := principal(c)
+	if err := h.service.Delete(c.Request.Context(), p.UserID, c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Expiring godoc
+// @Summary Recipes that use soon-to-expire pantry items
+// @Description Recipes using items that expire within the given number of days, the soonest expiry first,
+// @Description then by the number of such items and by pantry coverage. Already expired items are ignored.
+// @Tags Pantry
+// @Security BearerAuth
+// @Produce json
+// @Param days query int false "Expiry horizon in days" default(3) maximum(30)
+// @Param limit query int false "Max number of recipes" default(20)
+// @Success 200 {array} dto.ExpiringMatchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /pantry/expiring [get]
+func (h *PantryHandler) Expiring(c *gin.Context) {
+	days, limit := 0, 20
+	if v := c.Query("days"); v != "" {
+		var err error
+		if days, err = rest.ParseIntParam(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days"})
+			return
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		var err error
+		if limit, err = rest.ParseIntParam(v); err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+
+	p, _ := principal(c)
+	matches, err := h.service.Expiring(c.Request.Context(), p.UserID, days, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	results := make([]dto.ExpiringMatchResponse, 0, len(matches))
+	for _, m := range matches {
+		results = append(results, *dto.NewExpiringMatchFromModel(&m))
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// Cooked godoc
+// @Summary Mark a recipe as cooked and take its ingredients from the pantry
+// @Description Amounts are converted to the unit of each pantry item; items expiring sooner are used first, expired ones are left alone,
+// @Description and items that run out are removed. Ingredients not found in the pantry are reported as missing.
+// @Tags Pantry
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param servings query int false "Number of servings cooked; defaults to the recipe servings"
+// @Success 200 {object} dto.CookedReportResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /recipes/{id}/cooked [post]
+func (h *PantryHandler) Cooked(c *gin.Context) {
+	servings := 0
+	if v := c.Query("servings"); v != "" {
+		var err error
+		if servings, err = rest.ParseIntParam(v); err != nil || servings <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid servings"})
+			return
+		}
+	}
+
+	p, _ := principal(c)
+	report, err := h.service.Cooked(c.Request.Context(), p.UserID, c.Param("id"), servings)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewCookedReportFromModel(report))
+}
+
+func bindPantryItem(c *gin.Context) (*model.PantryItem, bool) {
+	var input dto.PantryItemRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	p, _ := principal(c)
+	item := &model.PantryItem{
+		UserID:       p.UserID,
+		IngredientID: input.IngredientID,
+		Quantity:     input.Quantity,
+		Unit:         input.Unit,
+	}
+	if input.ExpiresOn != "" {
+		date, err := time.Parse(time.DateOnly, input.ExpiresOn)
+		if err != nil {
+			writeError(c, puberr.NewPubErr("expires_on must be YYYY-MM-DD"))
+			return nil, false
+		}
+		item.ExpiresOn = &date
+	}
+	return item, true
+}
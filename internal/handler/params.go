@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/rest"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// queryList собирает значения параметра, переданного как повторяющийся (?id=a&id=b) или через запятую (?id=a,b)
+func queryList(c *gin.Context, key string) []string {
+	var result []string
+	for _, value := range c.QueryArray(key) {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// queryFloats читает числовые параметры prefix+name для каждого из names; отсутствующие пропускаются
+func queryFloats(c *gin.Context, prefix string, names []string) (map[string]float64, error) {
+	result := make(map[string]float64)
+	for _, name := range names {
+		v := c.Query(prefix + name)
+		if v == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, puberr.NewPubErr("invalid " + prefix + name)
+		}
+		result[name] = f
+	}
+	return result, nil
+}
+
+// parsePage читает общие для всех списков параметры limit, cursor, sort и order
+func parsePage(c *gin.Context, defaultSort string, defaultDesc bool) (model.PageRequest, error) {
+	page := model.PageRequest{
+		Cursor: c.Query("cursor"),
+		Sort:   c.DefaultQuery("sort", defaultSort),
+		Desc:   defaultDesc,
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := rest.ParseIntParam(v)
+		if err != nil || limit <= 0 {
+			return page, puberr.NewPubErr("invalid limit")
+		}
+		page.Limit = limit
+	}
+
+	switch c.Query("order") {
+	case "":
+	case "asc":
+		page.Desc = false
+	case "desc":
+		page.Desc = true
+	default:
+		return page, puberr.NewPubErr("order must be asc or desc")
+	}
+
+	return page, nil
+}
+
+// queryDate читает дату в формате YYYY-MM-DD; отсутствующий параметр — нулевое время
+func queryDate(c *gin.Context, key string) (time.Time, error) {
+	v := c.Query(key)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return time.Time{}, puberr.NewPubErr(key + " must be YYYY-MM-DD")
+	}
+	return date, nil
+}
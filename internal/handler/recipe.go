@@ -4,8 +4,12 @@ import (
 	"CookFinder.Backend/internal/model"
 	"CookFinder.Backend/internal/service"
 	"CookFinder.Backend/pkg/dto"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/rest"
 	"CookFinder.Backend/pkg/uuid"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,15 +19,18 @@ type RecipeHandler struct {
 	service *service.RecipeService
 }
 
-func NewRecipeHandler(r *gin.Engine, svc *service.RecipeService) {
+func NewRecipeHandler(r *gin.Engine, svc *service.RecipeService, auth *AuthMiddleware) {
 	h := &RecipeHandler{service: svc}
 	routes := r.Group("/recipes")
 	{
-		routes.POST("", h.Create)
+		routes.POST("", auth.RequireEditor(), h.Create)
 		routes.GET("", h.GetAll)
+		routes.GET("pantry", h.FindByPantry)
+		routes.GET("mine", auth.RequireRoles(), h.GetMine)
 		routes.GET(":id", h.GetByID)
-		routes.PUT(":id", h.Update)
-		routes.DELETE(":id", h.Delete)
+		// владелец проверяется в сервисе
+		routes.PUT(":id", auth.RequireRoles(), h.Update)
+		routes.DELETE(":id", auth.RequireRoles(), h.Delete)
 	}
 }
 
@@ -31,23 +38,179 @@ func NewRecipeHandler(r *gin.Engine, svc *service.RecipeService) {
 // @Summary Get all recipes
 // @Tags Recipes
 // @Produce json
-// @Param search query string false "Search by title or ingredient"
-// @Param category_id query string false "Filter by category ID"
-// @Success 200 {array} dto.RecipeResponse
+// @Param search query string false "Full-text search by title, ingredients and method (websearch syntax)"
+// @Param category_id query string false "Filter by category ID, matches any of the recipe categories"
+// @Param tags query []string false "Tag IDs, all of them must be set on the recipe" collectionFormat(csv)
+// @Param author_id query string false "Filter by author ID"
+// @Param diet query []string false "Required diet labels, e.g. vegan, vegetarian, gluten-free, lactose-free, nut-free or a manual tag" collectionFormat(csv)
+// @Param exclude_allergens query []string false "Skip recipes with ingredients containing these allergens" collectionFormat(csv) Enums(gluten, milk, eggs, nuts, peanuts, soy, fish, shellfish, sesame, celery, mustard)
+// @Param exclude_ingredients query []string false "Skip recipes containing any of these ingredient IDs" collectionFormat(csv)
+// @Param min_energy query number false "Min kcal per serving"
+// @Param max_energy query number false "Max kcal per serving"
+// @Param min_protein query number false "Min protein per serving, g"
+// @Param max_protein query number false "Max protein per serving, g"
+// @Param min_fat query number false "Min fat per serving, g"
+// @Param max_fat query number false "Max fat per serving, g"
+// @Param min_carbs query number false "Min carbohydrates per serving, g"
+// @Param max_carbs query number false "Max carbohydrates per serving, g"
+// @Param min_fiber query number false "Min fiber per serving, g"
+// @Param max_fiber query number false "Max fiber per serving, g"
+// @Param min_sugar query number false "Min sugar per serving, g"
+// @Param max_sugar query number false "Max sugar per serving, g"
+// @Param min_sodium query number false "Min sodium per serving, mg"
+// @Param max_sodium query number false "Max sodium per serving, mg"
+// @Param limit query int false "Page size" default(20) maximum(100)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param sort query string false "Sort field, relevance only with search; defaults to relevance when searching" Enums(relevance, created_at, title, cook_time, energy, rating) default(created_at)
+// @Param order query string false "Sort order" Enums(asc, desc) default(desc)
+// @Param units query string false "Render ingredient amounts in this system" Enums(metric, imperial)
+// @Success 200 {object} dto.Page{items=[]dto.RecipeResponse}
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /recipes [get]
 func (h *RecipeHandler) GetAll(c *gin.Context) {
-	search := c.Query("search")
-	categoryID := c.Query("category_id")
-	recipes, err := h.service.GetAll(c.Request.Context(), search, categoryID)
+	filter := model.RecipeFilter{
+		Search:     strings.TrimSpace(c.Query("search")),
+		CategoryID: c.Query("category_id"),
+		AuthorID:   c.Query("author_id"),
+
+		Diet:               queryList(c, "diet"),
+		ExcludeAllergens:   queryList(c, "exclude_allergens"),
+		ExcludeIngredients: queryList(c, "exclude_ingredients"),
+		Tags:               queryList(c, "tags"),
+	}
+
+	var err error
+	if filter.MinNutrients, err = queryFloats(c, "min_", model.RecipeNutrients); err != nil {
+		writeError(c, err)
+		return
+	}
+	if filter.MaxNutrients, err = queryFloats(c, "max_", model.RecipeNutrients); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	h.list(c, filter)
+}
+
+// GetMine godoc
+// @Summary Get recipes authored by the current user
+// @Tags Recipes
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Page size" default(20) maximum(100)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param sort query string false "Sort field" Enums(created_at, title, cook_time, energy, rating) default(created_at)
+// @Param order query string false "Sort order" Enums(asc, desc) default(desc)
+// @Param units query string false "Render ingredient amounts in this system" Enums(metric, imperial)
+// @Success 200 {object} dto.Page{items=[]dto.RecipeResponse}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /recipes/mine [get]
+func (h *RecipeHandler) GetMine(c *gin.Context) {
+	p, _ := principal(c)
+	h.list(c, model.RecipeFilter{AuthorID: p.UserID})
+}
+
+func (h *RecipeHandler) list(c *gin.Context, filter model.RecipeFilter) {
+	if p, ok := principal(c); ok {
+		filter.ViewerID = p.UserID
+	}
+
+	defaultSort := "created_at"
+	if filter.Search != "" {
+		defaultSort = "relevance"
+	}
+
+	page, err := parsePage(c, defaultSort, true)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	recipes, err := h.service.GetAll(c.Request.Context(), filter, page)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	if system := c.Query("units"); system != "" {
+		items := make([]*model.RecipeCategoryIngredients, len(recipes.Items))
+		for i := range recipes.Items {
+			items[i] = &recipes.Items[i]
+		}
+		if err := h.service.RenderUnits(c.Request.Context(), system, items...); err != nil {
+			writeError(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageFromModel(recipes, dto.NewRecipeResponseFromModel))
+}
+
+// FindByPantry godoc
+// @Summary Find recipes by pantry ingredients
+// @Description Ranks recipes by the share of their ingredients covered by ingredient_ids and lists the missing ones.
+// @Description An ingredient also counts as covered when a substitute for it is in the pantry; such ingredients are listed in substituted.
+// @Description Only substitutions with context "any" are used: the search does not know which dish the recipe is,
+// @Description so baking-only or sauce-only substitutes never count. GET /recipes/{id}/substitutions lists them all.
+// @Tags Recipes
+// @Produce json
+// @Param ingredient_ids query []string false "Ingredient IDs the user has" collectionFormat(csv)
+// @Param ingredients query []string false "Ingredient names typed by the user, resolved by fuzzy match" collectionFormat(csv)
+// @Param exclude_ids query []string false "Skip recipes containing any of these ingredient IDs" collectionFormat(csv)
+// @Param from_pantry query bool false "Also use the stored pantry of the current user, requires login"
+// @Param min_coverage query number false "Minimum share of covered ingredients, 0..1"
+// @Param limit query int false "Max number of recipes" default(50)
+// @Success 200 {array} dto.RecipeMatchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /recipes/pantry [get]
+func (h *RecipeHandler) FindByPantry(c *gin.Context) {
+	search := model.PantrySearch{
+		IngredientIDs:   queryList(c, "ingredient_ids"),
+		IngredientNames: queryList(c, "ingredients"),
+		ExcludeIDs:      queryList(c, "exclude_ids"),
+		Limit:           50,
+	}
+
+	if c.Query("from_pantry") == "true" {
+		p, ok := principal(c)
+		if !ok {
+			writeError(c, puberr.ErrNotAuthorized)
+			return
+		}
+		search.PantryUserID = p.UserID
+	}
+
+	if v := c.Query("min_coverage"); v != "" {
+		coverage, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid min_coverage"})
+			return
+		}
+		search.MinCoverage = coverage
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := rest.ParseIntParam(v)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		search.Limit = limit
+	}
+
+	matches, err := h.service.FindByPantry(c.Request.Context(), search)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	results := make([]dto.RecipeResponse, 0, len(recipes))
-	for _, r := range recipes {
-		results = append(results, *dto.NewRecipeResponseFromModel(&r))
+	results := make([]dto.RecipeMatchResponse, 0, len(matches))
+	for _, m := range matches {
+		results = append(results, *dto.NewRecipeMatchResponseFromModel(&m))
 	}
 
 	c.JSON(http.StatusOK, results)
@@ -58,17 +221,41 @@ func (h *RecipeHandler) GetAll(c *gin.Context) {
 // @Tags Recipes
 // @Produce json
 // @Param id path string true "Recipe ID"
+// @Param servings query int false "Scale ingredient amounts and nutrition to this number of servings"
+// @Param units query string false "Render ingredient amounts in this system" Enums(metric, imperial)
 // @Success 200 {object} dto.RecipeResponse
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Router /recipes/{id} [get]
 func (h *RecipeHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
-	recipe, err := h.service.GetByID(c.Request.Context(), id)
+
+	var (
+		recipe *model.RecipeCategoryIngredients
+		err    error
+	)
+	if v := c.Query("servings"); v != "" {
+		servings, convErr := rest.ParseIntParam(v)
+		if convErr != nil || servings <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid servings"})
+			return
+		}
+		recipe, err = h.service.GetScaled(c.Request.Context(), id, servings)
+	} else {
+		recipe, err = h.service.GetByID(c.Request.Context(), id)
+	}
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
 		return
 	}
 
+	if system := c.Query("units"); system != "" {
+		if err := h.service.RenderUnits(c.Request.Context(), system, recipe); err != nil {
+			writeError(c, err)
+			return
+		}
+	}
+
 	result := dto.NewRecipeResponseFromModel(recipe)
 	c.JSON(http.StatusOK, result)
 }
@@ -76,12 +263,15 @@ func (h *RecipeHandler) GetByID(c *gin.Context) {
 // Create godoc
 // @Summary Create a new recipe with ingredients
 // @Tags Recipes
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param recipe body dto.RecipeRequest true "Recipe data"
 // @Success 201 {object} dto.RecipeResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /recipes [post]
 func (h *RecipeHandler) Create(c *gin.Context) {
 	var input dto.RecipeRequest
@@ -91,17 +281,30 @@ func (h *RecipeHandler) Create(c *gin.Context) {
 	}
 
 	recipe := &model.Recipe{
-		ID:          uuid.V7().String(),
-		Title:       input.Title,
-		CategoryID:  input.CategoryID,
-		PrepTimeMin: input.PrepTimeMin,
-		CookTimeMin: input.CookTimeMin,
-		Method:      input.Method,
-		ImageURL:    input.ImageURL,
-		Protein:     input.Protein,
-		Fat:         input.Fat,
-		Energy:      input.Energy,
-		CreatedAt:   time.Now(),
+		ID:                uuid.V7().String(),
+		Title:             input.Title,
+		CategoryID:        input.CategoryID,
+		PrepTimeMin:       input.PrepTimeMin,
+		CookTimeMin:       input.CookTimeMin,
+		Method:            input.Method,
+		ImageURL:          input.ImageURL,
+		ImageFileID:       input.ImageFileID,
+		Protein:           input.Protein,
+		Fat:               input.Fat,
+		Energy:            input.Energy,
+		Carbs:             input.Carbs,
+		Fiber:             input.Fiber,
+		Sugar:             input.Sugar,
+		Sodium:            input.Sodium,
+		Servings:          input.Servings,
+		NutritionOverride: input.NutritionOverride,
+		DietTags:          input.DietTags,
+		CategoryIDs:       input.CategoryIDs,
+		TagIDs:            input.TagIDs,
+		CreatedAt:         time.Now(),
+	}
+	if p, ok := principal(c); ok {
+		recipe.AuthorID = p.UserID
 	}
 
 	ingredients := make([]model.RecipeIngredient, len(input.Ingredients))
@@ -113,8 +316,8 @@ func (h *RecipeHandler) Create(c *gin.Context) {
 		}
 	}
 
-	if err := h.service.CreateWithIngredients(c.Request.Context(), recipe, ingredients); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.CreateWithIngredients(c.Request.Context(), recipe, ingredients, dto.NewRecipeStepsFromRequest(input.Steps)); err != nil {
+		writeError(c, err)
 		return
 	}
 
@@ -130,13 +333,17 @@ func (h *RecipeHandler) Create(c *gin.Context) {
 // Update godoc
 // @Summary Update recipe by ID
 // @Tags Recipes
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path string true "Recipe ID"
 // @Param recipe body dto.RecipeRequest true "Recipe data"
-// @Success 200 {object} dto.RecipeResponse
+// @Success 204 {string} string "No Content"
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /recipes/{id} [put]
 func (h *RecipeHandler) Update(c *gin.Context) {
 	id := c.Param("id")
@@ -147,26 +354,28 @@ func (h *RecipeHandler) Update(c *gin.Context) {
 		return
 	}
 
-	// Проверим, существует ли рецепт
-	existing, err := h.service.GetByID(c.Request.Context(), id)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
-		return
-	}
-
 	// Обновлённые данные рецепта
 	updated := &model.Recipe{
-		ID:          id,
-		Title:       input.Title,
-		CategoryID:  input.CategoryID,
-		PrepTimeMin: input.PrepTimeMin,
-		CookTimeMin: input.CookTimeMin,
-		Method:      input.Method,
-		ImageURL:    input.ImageURL,
-		Protein:     input.Protein,
-		Fat:         input.Fat,
-		Energy:      input.Energy,
-		CreatedAt:   existing.Recipe.CreatedAt,
+		ID:                id,
+		Title:             input.Title,
+		CategoryID:        input.CategoryID,
+		PrepTimeMin:       input.PrepTimeMin,
+		CookTimeMin:       input.CookTimeMin,
+		Method:            input.Method,
+		ImageURL:          input.ImageURL,
+		ImageFileID:       input.ImageFileID,
+		Protein:           input.Protein,
+		Fat:               input.Fat,
+		Energy:            input.Energy,
+		Carbs:             input.Carbs,
+		Fiber:             input.Fiber,
+		Sugar:             input.Sugar,
+		Sodium:            input.Sodium,
+		Servings:          input.Servings,
+		NutritionOverride: input.NutritionOverride,
+		DietTags:          input.DietTags,
+		CategoryIDs:       input.CategoryIDs,
+		TagIDs:            input.TagIDs,
 	}
 
 	// Новые ингредиенты
@@ -180,8 +389,9 @@ func (h *RecipeHandler) Update(c *gin.Context) {
 	}
 
 	// Обновление рецепта и его ингредиентов
-	if err := h.service.UpdateWithIngredients(c.Request.Context(), updated, ingredients); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	p, _ := principal(c)
+	if err := h.service.UpdateWithIngredients(c.Request.Context(), p, updated, ingredients, dto.NewRecipeStepsFromRequest(input.Steps)); err != nil {
+		writeError(c, err)
 		return
 	}
 
@@ -191,15 +401,20 @@ func (h *RecipeHandler) Update(c *gin.Context) {
 // Delete godoc
 // @Summary Delete recipe by ID
 // @Tags Recipes
+// @Security BearerAuth
 // @Produce json
 // @Param id path string true "Recipe ID"
 // @Success 204 {string} string "No Content"
 // @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /recipes/{id} [delete]
 func (h *RecipeHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.service.Delete(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	p, _ := principal(c)
+	if err := h.service.Delete(c.Request.Context(), p, id); err != nil {
+		writeError(c, err)
 		return
 	}
 	c.Status(http.StatusNoContent)
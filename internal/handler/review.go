@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/service"
+	"CookFinder.Backend/pkg/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	service *service.ReviewService
+}
+
+func NewReviewHandler(r *gin.Engine, svc *service.ReviewService, auth *AuthMiddleware) {
+	h := &ReviewHandler{service: svc}
+	routes := r.Group("/recipes/:id/reviews")
+	{
+		routes.GET("", h.GetAll)
+		routes.PUT("", auth.RequireRoles(), h.Save)
+		routes.DELETE(":user_id", auth.RequireRoles(), h.Delete)
+	}
+}
+
+// GetAll godoc
+// @Summary Get reviews of a recipe
+// @Tags Reviews
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param limit query int false "Page size" default(20) maximum(100)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param sort query string false "Sort field" Enums(created_at, rating) default(created_at)
+// @Param order query string false "Sort order" Enums(asc, desc) default(desc)
+// @Success 200 {object} dto.Page{items=[]dto.ReviewResponse}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /recipes/{id}/reviews [get]
+func (h *ReviewHandler) GetAll(c *gin.Context) {
+	page, err := parsePage(c, "created_at", true)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	reviews, err := h.service.GetByRecipeID(c.Request.Context(), c.Param("id"), page)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageFromModel(reviews, dto.NewReviewFromModel))
+}
+
+// Save godoc
+// @Summary Rate and review a recipe
+// @Description Creates or replaces the review of the current user
+// @Tags Reviews
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param review body dto.ReviewRequest true "Rating 1-5 and optional text"
+// @Success 200 {object} dto.ReviewResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /recipes/{id}/reviews [put]
+func (h *ReviewHandler) Save(c *gin.Context) {
+	var input dto.ReviewRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p, _ := principal(c)
+	review, err := h.service.Save(c.Request.Context(), p.UserID, c.Param("id"), input.Rating, input.Body)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewReviewFromModel(review))
+}
+
+// Delete godoc
+// @Summary Delete a review
+// @Description Users can delete their own review, admins can delete any
+// @Tags Reviews
+// @Security BearerAuth
+// @Param id path string true "Recipe ID"
+// @Param user_id path string true "Author of the review"
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /recipes/{id}/reviews/{user_id} [delete]
+func (h *ReviewHandler) Delete(c *gin.Context) {
+	p, _ := principal(c)
+	if err := h.service.Delete(c.Request.Context(), p, c.Param("id"), c.Param("user_id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/service"
+	"CookFinder.Backend/pkg/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShoppingListHandler struct {
+	service *service.ShoppingListService
+}
+
+func NewShoppingListHandler(r *gin.Engine, svc *service.ShoppingListService, auth *AuthMiddleware) {
+	h := &ShoppingListHandler{service: svc}
+	r.GET("/shopping-lists/shared/:token", h.GetShared)
+
+	routes := r.Group("/shopping-lists", auth.RequireRoles())
+	{
+		routes.GET("", h.GetAll)
+		routes.POST("", h.Create)
+		routes.GET(":id", h.GetByID)
+		routes.DELETE(":id", h.Delete)
+		routes.PUT(":id/items/:item_id", h.SetChecked)
+		routes.POST(":id/share", h.Share)
+		routes.DELETE(":id/share", h.Unshare)
+	}
+}
+
+// GetAll godoc
+// @Summary Get shopping lists of the current user
+// @Tags ShoppingLists
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} dto.ShoppingListSummaryResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /shopping-lists [get]
+func (h *ShoppingListHandler) GetAll(c *gin.Context) {
+	p, _ := principal(c)
+
+	lists, err := h.service.GetAll(c.Request.Context(), p.UserID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	results := make([]dto.ShoppingListSummaryResponse, 0, len(lists))
+	for _, list := range lists {
+		results = append(results, *dto.NewShoppingListSummaryFromModel(&list))
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// Create godoc
+// @Summary Build a shopping list from recipes
+// @Description Amounts of the same ingredient are scaled to the requested servings, converted to one unit and summed across recipes.
+// @Description Items are grouped by store aisle.
+// @Tags ShoppingLists
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param list body dto.ShoppingListRequest true "Recipes and servings"
+// @Success 201 {object} dto.ShoppingListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /shopping-lists [post]
+func (h *ShoppingListHandler) Create(c *gin.Context) {
+	var input dto.ShoppingListRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recipes := make([]model.ShoppingListRecipe, 0, len(input.Recipes))
+	for _, r := range input.Recipes {
+		recipes = append(recipes, model.ShoppingListRecipe{RecipeID: r.RecipeID, Servings: r.Servings})
+	}
+
+	p, _ := principal(c)
+	list, err := h.service.Create(c.Request.Context(), p.UserID, input.Name, recipes)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewShoppingListFromModel(list))
+}
+
+// GetByID godoc
+// @Summary Get a shopping list with items grouped by aisle
+// @Tags ShoppingLists
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Shopping list ID"
+// @Success 200 {object} dto.ShoppingListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /shopping-lists/{id} [get]
+func (h *ShoppingListHandler) GetByID(c *gin.Context) {
+	p, _ := principal(c)
+
+	list, err := h.service.GetByID(c.Request.Context(), p.UserID, c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewShoppingListFromModel(list))
+}
+
+// GetShared godoc
+// @Summary Get a shared shopping list (read-only)
+// @Tags ShoppingLists
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} dto.ShoppingListResponse
+// @Failure 404 {object} map[string]string
+// @Router /shopping-lists/shared/{token} [get]
+func (h *ShoppingListHandler) GetShared(c *gin.Context) {
+	list, err := h.service.GetShared(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewShoppingListFromModel(list))
+}
+
+// SetChecked godoc
+// @Summary Check or uncheck a shopping list item
+// @Tags ShoppingLists
+// @Security BearerAuth
+// @Accept json
+// @Param id path string true "Shopping list ID"
+// @Param item_id path string true "Item ID"
+// @Param item body dto.ShoppingListItemRequest true "Check state"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /shopping-lists/{id}/items/{item_id} [put]
+func (h *ShoppingListHandler) SetChecked(c *gin.Context) {
+	var input dto.ShoppingListItemRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p, _ := principal(c)
+	if err := h.service.SetChecked(c.Request.Context(), p.UserID, c.Param("id"), c.Param("item_id"), input.Checked); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Share godoc
+// @Summary Create a read-only share link for a shopping list
+// @Description Returns the existing token if the list is already shared.
+// @Tags ShoppingLists
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Shopping list ID"
+// @Success 200 {object} dto.ShareTokenResponse
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /shopping-lists/{id}/share [post]
+func (h *ShoppingListHandler) Share(c *gin.Context) {
+	p, _ := principal(c)
+
+	token, err := h.service.Share(c.Request.Context(), p.UserID, c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ShareTokenResponse{ShareToken: token})
+}
+
+// Unshare godoc
+// @Summary Revoke the share link of a shopping list
+// @Tags ShoppingLists
+// @Security BearerAuth
+// @Param id path string true "Shopping list ID"
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /shopping-lists/{id}/share [delete]
+func (h *ShoppingListHandler) Unshare(c *gin.Context) {
+	p, _ := principal(c)
+	if err := h.service.Unshare(c.Request.Context(), p.UserID, c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Delete godoc
+// @Summary Delete a shopping list
+// @Tags ShoppingLists
+// @Security BearerAuth
+// @Param id path string true "Shopping list ID"
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /shopping-lists/{id} [delete]
+func (h *ShoppingListHandler) Delete(c *gin.Context) {
+	p, _ := principal(c)
+	if err := h.service.Delete(c.Request.Context(), p.UserID, c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/service"
+	"CookFinder.Backend/pkg/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SubstitutionHandler struct {
+	service *service.SubstitutionService
+}
+
+func NewSubstitutionHandler(r *gin.Engine, svc *service.SubstitutionService, auth *AuthMiddleware) {
+	h := &SubstitutionHandler{service: svc}
+	routes := r.Group("/substitutions")
+	{
+		routes.GET("", h.GetAll)
+		routes.GET(":id", h.GetByID)
+		routes.POST("", auth.RequireRoles(model.RoleAdmin), h.Create)
+		routes.PUT(":id", auth.RequireRoles(model.RoleAdmin), h.Update)
+		routes.DELETE(":id", auth.RequireRoles(model.RoleAdmin), h.Delete)
+	}
+	r.GET("/recipes/:id/substitutions", h.ForRecipe)
+}
+
+// GetAll godoc
+// @Summary Get ingredient substitutions
+// @Tags Substitutions
+// @Produce json
+// @Param ingredient_id query string false "Only substitutions for this ingredient"
+// @Param limit query int false "Page size" default(20) maximum(100)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Success 200 {object} dto.Page{items=[]dto.SubstitutionResponse}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /substitutions [get]
+func (h *SubstitutionHandler) GetAll(c *gin.Context) {
+	page, err := parsePage(c, "created_at", false)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	subs, err := h.service.GetAll(c.Request.Context(), c.Query("ingredient_id"), page)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageFromModel(subs, dto.NewSubstitutionResponseFromModel))
+}
+
+// GetByID godoc
+// @Summary Get ingredient substitution by ID
+// @Tags Substitutions
+// @Produce json
+// @Param id path string true "Substitution ID"
+// @Success 200 {object} dto.SubstitutionResponse
+// @Failure 404 {object} map[string]string
+// @Router /substitutions/{id} [get]
+func (h *SubstitutionHandler) GetByID(c *gin.Context) {
+	sub, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSubstitutionResponseFromModel(sub))
+}
+
+// Create godoc
+// @Summary Create an ingredient substitution
+// @Description Parts list the substitutes with their amount per unit of the original ingredient, e.g. buttermilk -> 0.9 milk + 0.1 lemon juice.
+// @Description A bidirectional substitution also works the other way round with the inverse ratio and must have exactly one part.
+// @Tags Substitutions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param substitution body dto.SubstitutionRequest true "Substitution body, context defaults to any"
+// @Success 201 {object} dto.SubstitutionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /substitutions [post]
+func (h *SubstitutionHandler) Create(c *gin.Context) {
+	var input dto.SubstitutionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, err := h.service.Create(c.Request.Context(), substitutionFromRequest(&input))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewSubstitutionResponseFromModel(sub))
+}
+
+// Update godoc
+// @Summary Update ingredient substitution by ID
+// @Tags Substitutions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Substitution ID"
+// @Param substitution body dto.SubstitutionRequest true "Substitution body"
+// @Success 200 {object} dto.SubstitutionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /substitutions/{id} [put]
+func (h *SubstitutionHandler) Update(c *gin.Context) {
+	var input dto.SubstitutionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub := substitutionFromRequest(&input)
+	sub.ID = c.Param("id")
+	sub, err := h.service.Update(c.Request.Context(), sub)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSubstitutionResponseFromModel(sub))
+}
+
+// Delete godoc
+// @Summary Delete ingredient substitution by ID
+// @Tags Substitutions
+// @Security BearerAuth
+// @Param id path string true "Substitution ID"
+// @Success 204 {string} string "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /substitutions/{id} [delete]
+func (h *SubstitutionHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ForRecipe godoc
+// @Summary Get substitutes for missing recipe ingredients
+// @Description For every recipe ingredient not in pantry lists the substitutions with amounts scaled to the recipe.
+// @Description Without pantry all ingredients are listed. Options whose substitutes are all in the pantry come first.
+// @Tags Substitutions
+// @Produce json
+// @Param id path string true "Recipe ID"
+// @Param pantry query []string false "Ingredient IDs the user has" collectionFormat(csv)
+// @Success 200 {array} dto.RecipeSubstitutionsResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /recipes/{id}/substitutions [get]
+func (h *SubstitutionHandler) ForRecipe(c *gin.Context) {
+	subs, err := h.service.ForRecipe(c.Request.Context(), c.Param("id"), queryList(c, "pantry"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewRecipeSubstitutionsFromModel(subs))
+}
+
+func substitutionFromRequest(input *dto.SubstitutionRequest) *model.IngredientSubstitution {
+	sub := &model.IngredientSubstitution{
+		IngredientID:  input.IngredientID,
+		Context:       input.Context,
+		Bidirectional: input.Bidirectional,
+		Notes:         input.Notes,
+		Parts:         make([]model.SubstitutionPart, len(input.Parts)),
+	}
+	for i, part := range input.Parts {
+		sub.Parts[i] = model.SubstitutionPart{
+			IngredientID: part.IngredientID,
+			Ratio:        part.Ratio,
+		}
+	}
+	return sub
+}
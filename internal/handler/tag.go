@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/service"
+	"CookFinder.Backend/pkg/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	service *service.TagService
+}
+
+func NewTagHandler(r *gin.Engine, svc *service.TagService, auth *AuthMiddleware) {
+	h := &TagHandler{service: svc}
+	routes := r.Group("/tags")
+	{
+		routes.GET("", h.GetAll)
+		routes.GET(":id", h.GetByID)
+		routes.POST("", auth.RequireEditor(), h.Create)
+		routes.PUT(":id", auth.RequireEditor(), h.Update)
+		routes.DELETE(":id", auth.RequireEditor(), h.Delete)
+	}
+}
+
+// GetAll godoc
+// @Summary Get all tags
+// @Tags Tags
+// @Produce json
+// @Param kind query string false "Filter by tag kind" Enums(cuisine, meal_type, occasion, other)
+// @Param limit query int false "Page size" default(20) maximum(100)
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param sort query string false "Sort field" Enums(created_at, name) default(name)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Success 200 {object} dto.Page{items=[]dto.Tag}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tags [get]
+func (h *TagHandler) GetAll(c *gin.Context) {
+	page, err := parsePage(c, "name", false)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	tags, err := h.service.GetAll(c.Request.Context(), c.Query("kind"), page)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageFromModel(tags, dto.NewTagFromModel))
+}
+
+// GetByID godoc
+// @Summary Get tag by ID
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Success 200 {object} dto.Tag
+// @Failure 404 {object} map[string]string
+// @Router /tags/{id} [get]
+func (h *TagHandler) GetByID(c *gin.Context) {
+	tag, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTagFromModel(tag))
+}
+
+// Create godoc
+// @Summary Create a new tag
+// @Tags Tags
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param tag body dto.Tag true "Tag body, kind defaults to other"
+// @Success 201 {object} dto.Tag
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /tags [post]
+func (h *TagHandler) Create(c *gin.Context) {
+	var input dto.Tag
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag := &model.Tag{
+		Name: input.Name,
+		Kind: input.Kind,
+	}
+	if err := h.service.Create(c.Request.Context(), tag); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewTagFromModel(tag))
+}
+
+// Update godoc
+// @Summary Update tag by ID
+// @Tags Tags
+// @Security BearerAuth
+// @Accept json
+// @Param id path string true "Tag ID"
+// @Param tag body dto.Tag true "Tag body"
+// @Success 204 {string} string "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /tags/{id} [put]
+func (h *TagHandler) Update(c *gin.Context) {
+	var input dto.Tag
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag := &model.Tag{
+		ID:   c.Param("id"),
+		Name: input.Name,
+		Kind: input.Kind,
+	}
+	if err := h.service.Update(c.Request.Context(), tag); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Delete godoc
+// @Summary Delete tag by ID
+// @Tags Tags
+// @Security BearerAuth
+// @Param id path string true "Tag ID"
+// @Success 204 {string} string "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tags/{id} [delete]
+func (h *TagHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
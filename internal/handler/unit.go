@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"CookFinder.Backend/internal/service"
+	"CookFinder.Backend/pkg/dto"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UnitHandler struct {
+	service *service.UnitService
+}
+
+func NewUnitHandler(r *gin.Engine, svc *service.UnitService) {
+	h := &UnitHandler{service: svc}
+	routes := r.Group("/units")
+	{
+		routes.GET("", h.GetAll)
+		routes.GET("convert", h.Convert)
+	}
+}
+
+// GetAll godoc
+// @Summary Get units of measure with aliases
+// @Tags Units
+// @Produce json
+// @Success 200 {array} dto.UnitResponse
+// @Failure 500 {object} map[string]string
+// @Router /units [get]
+func (h *UnitHandler) GetAll(c *gin.Context) {
+	units, err := h.service.GetAll(c.Request.Context())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	results := make([]dto.UnitResponse, 0, len(units))
+	for _, u := range units {
+		results = append(results, *dto.NewUnitFromModel(&u))
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// Convert godoc
+// @Summary Convert an amount between units
+// @Description Mass and volume can be converted into each other only for an ingredient with known density
+// @Tags Units
+// @Produce json
+// @Param amount query number true "Amount to convert"
+// @Param from query string true "Source unit code or alias"
+// @Param to query string true "Target unit code or alias"
+// @Param ingredient_id query string false "Ingredient whose density is used for volume-mass conversion"
+// @Success 200 {object} dto.ConversionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /units/convert [get]
+func (h *UnitHandler) Convert(c *gin.Context) {
+	amount, err := strconv.ParseFloat(c.Query("amount"), 64)
+	if err != nil || amount < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid amount"})
+		return
+	}
+
+	converted, unit, err := h.service.Convert(c.Request.Context(), amount, c.Query("from"), c.Query("to"), c.Query("ingredient_id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ConversionResponse{Amount: converted, Unit: unit.Code})
+}
@@ -0,0 +1,117 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// jpegOrientation читает тег Orientation (0x0112) из EXIF-блока APP1.
+// Возвращает 1, если тега нет или EXIF не удалось разобрать.
+func jpegOrientation(data []byte) int {
+	const orientationTag = 0x0112
+
+	// Маркеры идут подряд после SOI: FF xx, длина сегмента big-endian
+	for i := 2; i+4 <= len(data) && data[i] == 0xFF; {
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			break
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:], orientationTag)
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+func tiffOrientation(tiff []byte, tag uint16) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[ifd : ifd+2]))
+	for n := 0; n < count; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:entry+2]) == tag {
+			value := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// applyOrientation поворачивает изображение так, как его показал бы просмотрщик с учётом EXIF,
+// потому что после перекодирования тег Orientation пропадает
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// 5–8 — повороты на 90°, ширина и высота меняются местами
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	rgba, ok := src.(*image.RGBA)
+	if !ok || b.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // зеркально по горизонтали
+				dx, dy = w-1-x, y
+			case 3: // 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // зеркально по вертикали
+				dx, dy = x, h-1-y
+			case 5: // транспонирование
+				dx, dy = y, x
+			case 6: // 90° по часовой
+				dx, dy = h-1-y, x
+			case 7: // транспонирование по побочной диагонали
+				dx, dy = h-1-y, w-1-x
+			case 8: // 90° против часовой
+				dx, dy = y, w-1-x
+			}
+			si := rgba.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], rgba.Pix[si:si+4])
+		}
+	}
+	return dst
+}
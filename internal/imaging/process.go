@@ -0,0 +1,187 @@
+package imaging
+
+import (
+	"CookFinder.Backend/pkg/puberr"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+
+	"github.com/gen2brain/heic"
+	"github.com/gen2brain/webp"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MaxFileSize — предел размера загружаемого файла
+	MaxFileSize = 20 << 20
+	// MaxPixels защищает от «бомб», которые при декодировании занимают гигабайты памяти
+	MaxPixels = 50_000_000
+
+	jpegQuality = 85
+	webpQuality = 80
+)
+
+var (
+	ErrTooLarge          = puberr.NewPubErr(fmt.Sprintf("image must not exceed %d MB", MaxFileSize>>20)).SetHTTPCode(http.StatusRequestEntityTooLarge)
+	ErrUnsupportedFormat = puberr.NewPubErr("unsupported image format, expected JPEG, PNG, WebP or HEIC").SetHTTPCode(http.StatusUnsupportedMediaType)
+	ErrNoDecoder         = puberr.NewPubErr("this image format cannot be processed, please upload JPEG, PNG, WebP or HEIC").SetHTTPCode(http.StatusUnsupportedMediaType)
+	ErrTooManyPixels     = puberr.NewPubErr("image dimensions are too large").SetHTTPCode(http.StatusRequestEntityTooLarge)
+	ErrCorrupted         = puberr.NewPubErr("image is corrupted")
+)
+
+// Size — вариант изображения, длинная сторона вписывается в MaxSide; меньшие изображения не увеличиваются
+type Size struct {
+	Name    string
+	MaxSide int
+}
+
+// Sizes перечислены от большего к меньшему: каждый следующий вариант уменьшается из предыдущего
+var Sizes = []Size{
+	{Name: "full", MaxSide: 2048},
+	{Name: "card", MaxSide: 800},
+	{Name: "thumb", MaxSide: 320},
+}
+
+// Encoder сохраняет изображение в одном формате
+type Encoder struct {
+	Format   string // ключ в карте вариантов, например jpeg
+	MimeType string
+	Ext      string
+	Encode   func(w io.Writer, img image.Image) error
+}
+
+// encoders — форматы, в которых сохраняются варианты. WebP кодируется libwebp, собранной в WebAssembly,
+// поэтому cgo не нужен.
+var encoders = []Encoder{
+	{Format: "jpeg", MimeType: MimeJPEG, Ext: ".jpg", Encode: encodeJPEG},
+	{Format: "webp", MimeType: MimeWebP, Ext: ".webp", Encode: encodeWebP},
+}
+
+// Декодер HEIC регистрирует только brand heic, а Sniff принимает и остальные heicBrands
+func init() {
+	for _, brand := range heicBrands {
+		image.RegisterFormat("heic", "????ftyp"+string(brand), heic.Decode, heic.DecodeConfig)
+	}
+}
+
+// Rendition — один вариант изображения в одном формате
+type Rendition struct {
+	Size     string
+	Format   string
+	MimeType string
+	Ext      string
+	Width    int
+	Height   int
+	Data     []byte
+}
+
+// Result — исходные размеры и варианты, перекодированные без метаданных
+type Result struct {
+	MimeType   string // формат исходного файла
+	Width      int    // с учётом EXIF-поворота
+	Height     int
+	Renditions []Rendition
+}
+
+// Process проверяет сигнатуру и размеры, поворачивает изображение по EXIF и перекодирует его
+// во все Sizes и форматы encoders. Перекодирование отбрасывает EXIF, в том числе геометки.
+func Process(data []byte) (*Result, error) {
+	if len(data) > MaxFileSize {
+		return nil, ErrTooLarge
+	}
+
+	mimeType, ok := Sniff(data)
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return nil, ErrNoDecoder.SetCause(err)
+	}
+	if err != nil {
+		return nil, ErrCorrupted.SetCause(err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrCorrupted.SetCause(err)
+	}
+	if mimeType == MimeJPEG {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	bounds := img.Bounds()
+	result := &Result{
+		MimeType: mimeType,
+		Width:    bounds.Dx(),
+		Height:   bounds.Dy(),
+	}
+
+	src := img
+	for _, size := range Sizes {
+		src = fit(src, size.MaxSide)
+		for _, encoder := range encoders {
+			var buf bytes.Buffer
+			if err := encoder.Encode(&buf, src); err != nil {
+				return nil, fmt.Errorf("encode %s %s: %w", size.Name, encoder.Format, err)
+			}
+			result.Renditions = append(result.Renditions, Rendition{
+				Size:     size.Name,
+				Format:   encoder.Format,
+				MimeType: encoder.MimeType,
+				Ext:      encoder.Ext,
+				Width:    src.Bounds().Dx(),
+				Height:   src.Bounds().Dy(),
+				Data:     buf.Bytes(),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// fit уменьшает изображение так, чтобы длинная сторона не превышала maxSide
+func fit(src image.Image, maxSide int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxSide && h <= maxSide {
+		return src
+	}
+
+	if w >= h {
+		h = max(1, h*maxSide/w)
+		w = maxSide
+	} else {
+		w = max(1, w*maxSide/h)
+		h = maxSide
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, b, xdraw.Src, nil)
+	return dst
+}
+
+// encodeJPEG кладёт прозрачные области на белый фон: в JPEG нет альфа-канала
+func encodeJPEG(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	canvas := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(canvas, canvas.Bounds(), img, b.Min, draw.Over)
+	return jpeg.Encode(w, canvas, &jpeg.Options{Quality: jpegQuality})
+}
+
+func encodeWebP(w io.Writer, img image.Image) error {
+	return webp.Encode(w, img, webp.Options{Quality: webpQuality})
+}
@@ -0,0 +1,84 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"testing"
+)
+
+func TestProcess(t *testing.T) {
+	heicData, err := os.ReadFile("testdata/sample.heic")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// PNG шире full, чтобы проверить уменьшение
+	src := image.NewNRGBA(image.Rect(0, 0, 3000, 1500))
+	for x := 0; x < 3000; x++ {
+		src.Set(x, x%1500, color.NRGBA{R: 200, A: 255})
+	}
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, src); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		mimeType string
+		maxSide  int
+	}{
+		{name: "heic", data: heicData, mimeType: MimeHEIC},
+		{name: "png", data: pngData.Bytes(), mimeType: MimePNG, maxSide: 2048},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Process(tt.data)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if result.MimeType != tt.mimeType {
+				t.Errorf("MimeType = %q, want %q", result.MimeType, tt.mimeType)
+			}
+			if len(result.Renditions) != len(Sizes)*len(encoders) {
+				t.Fatalf("got %d renditions, want %d", len(result.Renditions), len(Sizes)*len(encoders))
+			}
+			if tt.maxSide > 0 && max(result.Renditions[0].Width, result.Renditions[0].Height) != tt.maxSide {
+				t.Errorf("full rendition is %dx%d, want long side %d", result.Renditions[0].Width, result.Renditions[0].Height, tt.maxSide)
+			}
+
+			for _, r := range result.Renditions {
+				mimeType, ok := Sniff(r.Data)
+				if !ok || mimeType != r.MimeType {
+					t.Errorf("%s %s: data sniffed as %q, want %q", r.Size, r.Format, mimeType, r.MimeType)
+					continue
+				}
+				config, _, err := image.DecodeConfig(bytes.NewReader(r.Data))
+				if err != nil {
+					t.Errorf("%s %s: %v", r.Size, r.Format, err)
+					continue
+				}
+				if config.Width != r.Width || config.Height != r.Height {
+					t.Errorf("%s %s: encoded %dx%d, reported %dx%d", r.Size, r.Format, config.Width, config.Height, r.Width, r.Height)
+				}
+			}
+		})
+	}
+}
+
+func TestIsAccepted(t *testing.T) {
+	for _, mimeType := range []string{MimeJPEG, MimePNG, MimeWebP, MimeHEIC, "image/heif"} {
+		if !IsAccepted(mimeType) {
+			t.Errorf("IsAccepted(%q) = false, want true", mimeType)
+		}
+	}
+	for _, mimeType := range []string{"image/gif", "image/avif", ""} {
+		if IsAccepted(mimeType) {
+			t.Errorf("IsAccepted(%q) = true, want false", mimeType)
+		}
+	}
+}
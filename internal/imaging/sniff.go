@@ -0,0 +1,65 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"sync"
+)
+
+// Форматы, которые принимаются при загрузке
+const (
+	MimeJPEG = "image/jpeg"
+	MimePNG  = "image/png"
+	MimeWebP = "image/webp"
+	MimeHEIC = "image/heic"
+)
+
+// heicBrands — major brand в ftyp-блоке HEIF-контейнера с HEVC-кодированием
+var heicBrands = [][]byte{[]byte("heic"), []byte("heix"), []byte("heim"), []byte("heis"), []byte("hevc"), []byte("hevx"), []byte("mif1"), []byte("msf1")}
+
+// Sniff определяет формат по сигнатуре файла; имя и Content-Type от клиента не учитываются
+func Sniff(data []byte) (string, bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return MimeJPEG, true
+	case bytes.HasPrefix(data, []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}):
+		return MimePNG, true
+	case len(data) >= 12 && bytes.Equal(data[0:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP")):
+		return MimeWebP, true
+	case len(data) >= 12 && bytes.Equal(data[4:8], []byte("ftyp")):
+		for _, brand := range heicBrands {
+			if bytes.Equal(data[8:12], brand) {
+				return MimeHEIC, true
+			}
+		}
+	}
+	return "", false
+}
+
+// signatures — начало файла каждого формата, по которому image находит декодер
+var signatures = map[string][]byte{
+	MimeJPEG: {0xFF, 0xD8, 0xFF},
+	MimePNG:  {0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'},
+	MimeWebP: []byte("RIFF\x00\x00\x00\x00WEBPVP8 "),
+	MimeHEIC: []byte("\x00\x00\x00\x18ftypheic"),
+}
+
+// decodable — форматы, для которых зарегистрирован декодер: на обрезанном заголовке декодер
+// возвращает ошибку чтения, а при его отсутствии image возвращает image.ErrFormat
+var decodable = sync.OnceValue(func() map[string]bool {
+	result := make(map[string]bool, len(signatures))
+	for mimeType, signature := range signatures {
+		_, _, err := image.DecodeConfig(bytes.NewReader(signature))
+		result[mimeType] = !errors.Is(err, image.ErrFormat)
+	}
+	return result
+})
+
+// IsAccepted сообщает, принимается ли формат при загрузке: только те, что Process сможет декодировать
+func IsAccepted(mimeType string) bool {
+	if mimeType == "image/heif" {
+		mimeType = MimeHEIC
+	}
+	return decodable()[mimeType]
+}
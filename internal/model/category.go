@@ -1,7 +1,8 @@
 package model
 
 type Category struct {
-	ID       string `db:"id"`
-	Name     string `db:"name"`
-	ImageUrl string `db:"image_url"`
+	ID          string `db:"id"`
+	Name        string `db:"name"`
+	ImageUrl    string `db:"image_url"`
+	ImageFileID string `db:"image_file_id"` // files.id картинки
 }
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+type Collection struct {
+	ID          string    `db:"id"`
+	UserID      string    `db:"user_id"`
+	Name        string    `db:"name"`
+	CreatedAt   time.Time `db:"created_at"`
+	RecipeCount int       `db:"recipe_count"`
+}
@@ -0,0 +1,75 @@
+package model
+
+import "slices"
+
+// Метки, которые выводятся из ингредиентов рецепта
+const (
+	DietVegan       = "vegan"
+	DietVegetarian  = "vegetarian"
+	DietGlutenFree  = "gluten-free"
+	DietLactoseFree = "lactose-free"
+	DietNutFree     = "nut-free"
+)
+
+// Allergens — допустимые значения ingredients.allergens
+var Allergens = []string{"gluten", "milk", "eggs", "nuts", "peanuts", "soy", "fish", "shellfish", "sesame", "celery", "mustard"}
+
+// DietAllergens — метки, которые ставятся, если в рецепте нет ни одного из перечисленных аллергенов
+var DietAllergens = map[string][]string{
+	DietGlutenFree:  {"gluten"},
+	DietLactoseFree: {"milk"},
+	DietNutFree:     {"nuts", "peanuts"},
+}
+
+func IsValidAllergen(allergen string) bool {
+	return slices.Contains(Allergens, allergen)
+}
+
+// IsDerivedDiet сообщает, что метка вычисляется по ингредиентам и не может быть задана вручную
+func IsDerivedDiet(tag string) bool {
+	_, ok := DietAllergens[tag]
+	return ok || tag == DietVegan || tag == DietVegetarian
+}
+
+// DietLabels объединяет метки, выведенные из ингредиентов, с ручными метками рецепта.
+// Метки выводятся, только если у рецепта есть ингредиенты и все они проверены (AllergensReviewed):
+// о непроверенном ингредиенте ничего не известно, и ставить «без орехов» по нему нельзя.
+func DietLabels(manual []string, ingredients []IngredientWithAmount) []string {
+	labels := make([]string, 0, len(DietAllergens)+2+len(manual))
+	classified := len(ingredients) > 0 && !slices.ContainsFunc(ingredients, func(ing IngredientWithAmount) bool { return !ing.AllergensReviewed })
+	if classified {
+		labels = derivedDietLabels(labels, ingredients)
+	}
+
+	for _, tag := range manual {
+		if !slices.Contains(labels, tag) {
+			labels = append(labels, tag)
+		}
+	}
+	return labels
+}
+
+func derivedDietLabels(labels []string, ingredients []IngredientWithAmount) []string {
+	vegan, vegetarian := true, true
+	present := make(map[string]bool)
+	for _, ing := range ingredients {
+		vegan = vegan && !ing.IsAnimal
+		vegetarian = vegetarian && !ing.IsMeat
+		for _, allergen := range ing.Allergens {
+			present[allergen] = true
+		}
+	}
+
+	if vegan {
+		labels = append(labels, DietVegan)
+	}
+	if vegetarian {
+		labels = append(labels, DietVegetarian)
+	}
+	for _, diet := range []string{DietGlutenFree, DietLactoseFree, DietNutFree} {
+		if !slices.ContainsFunc(DietAllergens[diet], func(a string) bool { return present[a] }) {
+			labels = append(labels, diet)
+		}
+	}
+	return labels
+}
@@ -1,7 +1,81 @@
 package model
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
 type File struct {
-	ID   string `db:"id" json:"id"`
-	Name string `db:"name" json:"name"`
-	Path string `db:"path" json:"path"`
+	ID         string     `db:"id" json:"id"`
+	Name       string     `db:"name" json:"name"`
+	Path       string     `db:"path" json:"path"` // URL варианта full/jpeg, у старых файлов — исходника
+	MimeType   string     `db:"mime_type" json:"mime_type"`
+	Width      int        `db:"width" json:"width"`
+	Height     int        `db:"height" json:"height"`
+	Renditions Renditions `db:"renditions" json:"renditions"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+}
+
+// FileRendition — уменьшенная копия изображения в одном формате
+type FileRendition struct {
+	URL      string `json:"url"`
+	Key      string `json:"key"` // имя объекта в хранилище
+	MimeType string `json:"mime_type"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
+// Renditions — варианты по размеру (thumb, card, full) и формату (jpeg, webp), хранятся в JSONB
+type Renditions map[string]map[string]FileRendition
+
+func (it Renditions) Value() (driver.Value, error) {
+	if it == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(it)
+}
+
+func (it *Renditions) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*it = Renditions{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, it)
+	case string:
+		return json.Unmarshal([]byte(v), it)
+	default:
+		return fmt.Errorf("cannot scan %T into Renditions", src)
+	}
+}
+
+// Keys возвращает имена всех объектов вариантов в хранилище
+func (it Renditions) Keys() []string {
+	var keys []string
+	for _, formats := range it {
+		for _, rendition := range formats {
+			keys = append(keys, rendition.Key)
+		}
+	}
+	return keys
+}
+
+// PresignedUpload — ссылка для загрузки исходника напрямую в хранилище
+type PresignedUpload struct {
+	Key         string
+	URL         string
+	ContentType string // клиент должен передать этот Content-Type в PUT
+	ExpiresAt   time.Time
+}
+
+// SweepReport — результат поиска неиспользуемых файлов и объектов хранилища
+type SweepReport struct {
+	DryRun         bool
+	Before         time.Time // учитываются только файлы и объекты старше этого момента
+	Files          []File    // записи files, на которые никто не ссылается
+	Objects        []string  // объекты хранилища без записи в files
+	DeletedFiles   int
+	DeletedObjects int
 }
@@ -1,7 +1,33 @@
 package model
 
+import "github.com/lib/pq"
+
 type Ingredient struct {
 	ID       string `db:"id"`
 	Name     string `db:"name"`
 	ImageUrl string `db:"image_url"`
+	// ImageFileID — files.id картинки, ImageUrl заполняется из files.path
+	ImageFileID string `db:"image_file_id"`
+	// DensityGPerMl — плотность для пересчёта объёма в массу, 0 если неизвестна
+	DensityGPerMl float64 `db:"density_g_per_ml"`
+	// UnitWeightG — масса одной штуки для ингредиентов в pcs, 0 если неизвестна
+	UnitWeightG float64        `db:"unit_weight_g"`
+	Allergens   pq.StringArray `db:"allergens"` // значения из Allergens
+	IsAnimal    bool           `db:"is_animal"` // продукт животного происхождения
+	IsMeat      bool           `db:"is_meat"`   // мясо, птица, рыба или морепродукты
+	// AllergensReviewed — Allergens, IsAnimal и IsMeat проверены; без этого их значения считаются неизвестными
+	AllergensReviewed bool   `db:"allergens_reviewed"`
+	Aisle             string `db:"aisle"` // отдел магазина, значения из Aisles
+	Nutrients
+}
+
+// Nutrients — пищевая ценность на 100 г продукта
+type Nutrients struct {
+	Kcal    float64 `db:"kcal_per_100g"`
+	Protein float64 `db:"protein_per_100g"`
+	Fat     float64 `db:"fat_per_100g"`
+	Carbs   float64 `db:"carbs_per_100g"`
+	Fiber   float64 `db:"fiber_per_100g"`
+	Sugar   float64 `db:"sugar_per_100g"`
+	Salt    float64 `db:"salt_per_100g"`
 }
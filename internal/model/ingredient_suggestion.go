@@ -0,0 +1,7 @@
+package model
+
+type IngredientSuggestion struct {
+	Ingredient
+	Score  float64 `db:"score"`     // pg_trgm similarity, 0..1
+	Prefix bool    `db:"is_prefix"` // название начинается с запроса
+}
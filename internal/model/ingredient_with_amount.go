@@ -1,9 +1,16 @@
 package model
 
+import "github.com/lib/pq"
+
 type IngredientWithAmount struct {
-	ID       string `db:"id"`        // ingredients.id
-	Name     string `db:"name"`      // ingredients.name
-	ImageURL string `db:"image_url"` // ingredients.image_url
-	Amount   int    `db:"amount"`    // recipe_ingredients.amount
-	Unit     string `db:"unit"`      // recipe_ingredients.unit
+	ID        string         `db:"id"`        // ingredients.id
+	Name      string         `db:"name"`      // ingredients.name
+	ImageURL  string         `db:"image_url"` // ingredients.image_url
+	Amount    float64        `db:"amount"`    // recipe_ingredients.amount
+	Unit      string         `db:"unit"`      // recipe_ingredients.unit
+	Allergens pq.StringArray `db:"allergens"` // ingredients.allergens
+	IsAnimal  bool           `db:"is_animal"` // ingredients.is_animal
+	IsMeat    bool           `db:"is_meat"`   // ingredients.is_meat
+	// AllergensReviewed — ingredients.allergens_reviewed
+	AllergensReviewed bool `db:"allergens_reviewed"`
 }
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	MealSlotBreakfast = "breakfast"
+	MealSlotLunch     = "lunch"
+	MealSlotDinner    = "dinner"
+	MealSlotSnack     = "snack"
+)
+
+// MealSlots — приёмы пищи в порядке в течение дня
+var MealSlots = []string{MealSlotBreakfast, MealSlotLunch, MealSlotDinner, MealSlotSnack}
+
+func IsValidMealSlot(slot string) bool {
+	return slices.Contains(MealSlots, slot)
+}
+
+type MealPlanEntry struct {
+	ID             string    `db:"id"`
+	UserID         string    `db:"user_id"`
+	Date           time.Time `db:"date"` // только дата, без времени
+	Slot           string    `db:"slot"`
+	RecipeID       string    `db:"recipe_id"`
+	Servings       int       `db:"servings"`
+	CreatedAt      time.Time `db:"created_at"`
+	RecipeTitle    string    `db:"recipe_title"`
+	RecipeImageURL string    `db:"recipe_image_url"`
+	MealNutrition            // пищевая ценность рецепта, пересчитанная на Servings порций
+}
+
+// MealNutrition — пищевая ценность приёма пищи или суммы за день
+type MealNutrition struct {
+	Energy  float64 `db:"energy"` // ккал
+	Fat     float64 `db:"fat"`
+	Protein float64 `db:"protein"`
+	Carbs   float64 `db:"carbs"`
+	Fiber   float64 `db:"fiber"`
+	Sugar   float64 `db:"sugar"`
+	Sodium  float64 `db:"sodium"` // мг
+}
+
+func (it *MealNutrition) Add(other MealNutrition) {
+	it.Energy += other.Energy
+	it.Fat += other.Fat
+	it.Protein += other.Protein
+	it.Carbs += other.Carbs
+	it.Fiber += other.Fiber
+	it.Sugar += other.Sugar
+	it.Sodium += other.Sodium
+}
+
+type MealPlanDay struct {
+	Date    time.Time
+	Entries []MealPlanEntry
+	Totals  MealNutrition
+}
+
+type MealPlanWeek struct {
+	Start  time.Time // понедельник или дата, с которой запрошена неделя
+	Days   []MealPlanDay
+	Totals MealNutrition
+}
+
+// MealPlanConstraints — условия для автоматического составления плана на неделю
+type MealPlanConstraints struct {
+	Start              time.Time
+	Slots              []string // приёмы пищи каждого дня, по умолчанию завтрак, обед и ужин
+	Servings           int      // порций каждого блюда; калории и макросы задаются на одного человека
+	EnergyPerDay       float64  // ккал на человека в день
+	ProteinPercent     float64  // доли энергии из белков, жиров и углеводов; все нули — без ограничения
+	FatPercent         float64
+	CarbsPercent       float64
+	Diet               []string
+	ExcludeAllergens   []string
+	ExcludeIngredients []string
+	NoRepeatDays       int   // рецепт не повторяется чаще, чем раз в столько дней
+	Seed               int64 // одинаковый seed при тех же рецептах даёт тот же план
+	Save               bool  // сразу записать предложенный план
+}
+
+// GeneratedMealPlan — предложенный план; записи без ID, если план не сохранён
+type GeneratedMealPlan struct {
+	MealPlanWeek
+	Seed   int64
+	Target MealNutrition // цель на человека в день
+	Saved  bool
+}
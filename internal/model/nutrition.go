@@ -0,0 +1,13 @@
+package model
+
+// Nutrition — пищевая ценность, рассчитанная по ингредиентам рецепта
+type Nutrition struct {
+	Energy  float64 // ккал
+	Protein float64 // г
+	Fat     float64
+	Carbs   float64
+	Fiber   float64
+	Sugar   float64
+	Salt    float64
+	Skipped []string // ID ингредиентов, которые не удалось перевести в граммы
+}
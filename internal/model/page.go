@@ -0,0 +1,13 @@
+package model
+
+type PageRequest struct {
+	Limit  int
+	Cursor string // непрозрачный курсор из Page.NextCursor предыдущей страницы
+	Sort   string
+	Desc   bool
+}
+
+type Page[T any] struct {
+	Items      []T
+	NextCursor string // пустой, если страниц больше нет
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// PantryItem — партия ингредиента у пользователя
+type PantryItem struct {
+	ID           string     `db:"id"`
+	UserID       string     `db:"user_id"`
+	IngredientID string     `db:"ingredient_id"`
+	Name         string     `db:"name"`
+	ImageURL     string     `db:"image_url"`
+	Quantity     float64    `db:"quantity"`
+	Unit         string     `db:"unit"`
+	ExpiresOn    *time.Time `db:"expires_on"` // только дата; nil — срок не указан
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+// ExpiringSearch — поиск рецептов, в которых используются запасы с истекающим сроком
+type ExpiringSearch struct {
+	UserID string
+	Today  time.Time // запасы с истёкшим сроком не учитываются
+	Until  time.Time // последний день, когда срок ещё считается истекающим
+	Limit  int
+}
+
+type ExpiringMatch struct {
+	RecipeMatch
+	ExpiringCount int       `db:"expiring_count"`
+	SoonestExpiry time.Time `db:"soonest_expiry"`
+	Expiring      []IngredientWithAmount
+}
+
+// CookedReport — что списано из запасов после приготовления рецепта
+type CookedReport struct {
+	RecipeID string
+	Servings int
+	Usage    []PantryUsage
+}
+
+// PantryUsage — сколько ингредиента нужно по рецепту и сколько удалось списать, в единице рецепта
+type PantryUsage struct {
+	IngredientID string
+	Name         string
+	Required     float64
+	Consumed     float64
+	Unit         string
+}
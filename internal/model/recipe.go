@@ -2,18 +2,34 @@ package model
 
 import (
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type Recipe struct {
-	ID          string    `db:"id"`
-	Title       string    `db:"title"`
-	CategoryID  string    `db:"category_id"`
-	PrepTimeMin int       `db:"prep_time_min"`
-	CookTimeMin int       `db:"cook_time_min"`
-	Method      string    `db:"method"`
-	Energy      int       `db:"energy"`
-	Fat         float64   `db:"fat"`
-	Protein     float64   `db:"protein"`
-	CreatedAt   time.Time `db:"created_at"`
-	ImageURL    string    `db:"image_url"`
+	ID                string         `db:"id"`
+	Title             string         `db:"title"`
+	CategoryID        string         `db:"category_id"`
+	PrepTimeMin       int            `db:"prep_time_min"`
+	CookTimeMin       int            `db:"cook_time_min"`
+	Method            string         `db:"method"`
+	Energy            int            `db:"energy"`
+	Fat               float64        `db:"fat"`
+	Protein           float64        `db:"protein"`
+	Carbs             float64        `db:"carbs"`
+	Fiber             float64        `db:"fiber"`
+	Sugar             float64        `db:"sugar"`
+	Sodium            float64        `db:"sodium"`             // мг
+	Servings          int            `db:"servings"`           // на сколько порций рассчитаны ингредиенты и пищевая ценность
+	NutritionOverride bool           `db:"nutrition_override"` // пищевая ценность введена вручную и не пересчитывается по ингредиентам
+	NutritionSkipped  pq.StringArray `db:"nutrition_skipped"`  // ингредиенты, не попавшие в расчёт пищевой ценности; непустой — значения занижены
+	CreatedAt         time.Time      `db:"created_at"`
+	ImageURL          string         `db:"image_url"`
+	ImageFileID       string         `db:"image_file_id"` // files.id; ImageURL заполняется из files.path
+	AuthorID          string         `db:"author_id"`     // пустой у рецептов, созданных до появления авторов
+	AvgRating         float64        `db:"avg_rating"`    // поддерживается триггером по recipe_reviews
+	RatingCount       int            `db:"rating_count"`
+	DietTags          pq.StringArray `db:"diet_tags"` // ручные метки, выведенные из ингредиентов сюда не попадают
+	CategoryIDs       []string       `db:"-"`         // все категории рецепта, первая совпадает с CategoryID
+	TagIDs            []string       `db:"-"`
 }
@@ -2,6 +2,19 @@ package model
 
 type RecipeCategoryIngredients struct {
 	Recipe      Recipe
-	Category    Category
+	Category    Category   // основная категория, оставлена для старых клиентов
+	Categories  []Category // все категории в порядке, заданном при сохранении
+	Tags        []Tag
 	Ingredients []IngredientWithAmount
+	Steps       []RecipeStep     // загружаются только для одного рецепта, в списках пустые
+	Rank        float64          // релевантность полнотекстового поиска
+	Highlight   *RecipeHighlight // заполняется только при поиске
+	IsFavorite  bool             // рецепт есть в одной из коллекций текущего пользователя
+	Diet        []string         // метки из ингредиентов и ручные метки, см. DietLabels
+}
+
+// RecipeHighlight содержит фрагменты с найденными словами, выделенными <b></b>
+type RecipeHighlight struct {
+	Title  string
+	Method string
 }
@@ -0,0 +1,21 @@
+package model
+
+type RecipeFilter struct {
+	Search     string
+	CategoryID string
+	AuthorID   string
+	ViewerID   string // текущий пользователь, для отметки избранного
+	// MinNutrients и MaxNutrients ограничивают пищевую ценность одной порции, ключи из RecipeNutrients
+	MinNutrients map[string]float64
+	MaxNutrients map[string]float64
+	// Diet — все перечисленные метки должны быть у рецепта; ExcludeAllergens — ни одного из аллергенов
+	Diet             []string
+	ExcludeAllergens []string
+	// Tags — ID тегов, все должны быть у рецепта
+	Tags []string
+	// ExcludeIngredients — ID ингредиентов, которых не должно быть в рецепте
+	ExcludeIngredients []string
+}
+
+// RecipeNutrients — показатели пищевой ценности рецепта, по которым можно фильтровать
+var RecipeNutrients = []string{"energy", "protein", "fat", "carbs", "fiber", "sugar", "sodium"}
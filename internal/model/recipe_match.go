@@ -0,0 +1,20 @@
+package model
+
+type PantrySearch struct {
+	IngredientIDs   []string // ингредиенты, которые есть у пользователя
+	IngredientNames []string // то же, но введённое текстом; сопоставляется со справочником по сходству
+	ExcludeIDs      []string // рецепты с этими ингредиентами не возвращаются
+	MinCoverage     float64  // доля ингредиентов рецепта, покрытых IngredientIDs (0..1)
+	SubstitutedIDs  []string // ингредиенты, которых нет, но которые можно заменить тем, что есть; заполняет сервис
+	PantryUserID    string   // если задан, к IngredientIDs добавляются неиспорченные запасы этого пользователя
+	Limit           int
+}
+
+type RecipeMatch struct {
+	RecipeCategoryIngredients
+	MatchedCount int     `db:"matched_count"`
+	TotalCount   int     `db:"total_count"`
+	Coverage     float64 `db:"coverage"`
+	Missing      []IngredientWithAmount
+	Substituted  []IngredientWithAmount // засчитаны как покрытые благодаря заменам
+}
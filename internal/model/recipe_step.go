@@ -0,0 +1,10 @@
+package model
+
+type RecipeStep struct {
+	RecipeID    string `db:"recipe_id"`
+	Ordinal     int    `db:"ordinal"` // с 1
+	Text        string `db:"text"`
+	DurationSec int    `db:"duration_sec"`  // 0 — без таймера
+	ImageFileID string `db:"image_file_id"` // files.id, пустой если фото нет
+	ImageURL    string `db:"image_url"`     // files.path, только при чтении
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type Review struct {
+	RecipeID  string    `db:"recipe_id"`
+	UserID    string    `db:"user_id"`
+	Rating    int       `db:"rating"` // 1..5
+	Body      string    `db:"body"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// Aisles — отделы магазина для группировки списка покупок, в порядке обхода
+var Aisles = []string{"produce", "meat", "fish", "dairy", "bakery", "grocery", "spices", "frozen", "drinks", "other"}
+
+const AisleOther = "other"
+
+func IsValidAisle(aisle string) bool {
+	return slices.Contains(Aisles, aisle)
+}
+
+type ShoppingList struct {
+	ID         string    `db:"id"`
+	UserID     string    `db:"user_id"`
+	Name       string    `db:"name"`
+	ShareToken string    `db:"share_token"`
+	CreatedAt  time.Time `db:"created_at"`
+	Recipes    []ShoppingListRecipe
+	Items      []ShoppingListItem
+}
+
+// ShoppingListRecipe — рецепт, из которого составлен список, и число порций
+type ShoppingListRecipe struct {
+	RecipeID string `db:"recipe_id"`
+	Title    string `db:"title"`
+	Servings int    `db:"servings"`
+}
+
+// ShoppingListItem — сумма одного ингредиента по всем рецептам списка
+type ShoppingListItem struct {
+	ID           string  `db:"id"`
+	IngredientID string  `db:"ingredient_id"`
+	Name         string  `db:"name"`
+	Aisle        string  `db:"aisle"`
+	Amount       float64 `db:"amount"`
+	Unit         string  `db:"unit"`
+	Checked      bool    `db:"checked"`
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// Контексты, в которых замена уместна
+const (
+	SubstitutionAny     = "any"
+	SubstitutionBaking  = "baking"
+	SubstitutionCooking = "cooking"
+	SubstitutionRaw     = "raw"
+	SubstitutionSauce   = "sauce"
+	SubstitutionDrinks  = "drinks"
+)
+
+func IsValidSubstitutionContext(context string) bool {
+	return slices.Contains([]string{SubstitutionAny, SubstitutionBaking, SubstitutionCooking, SubstitutionRaw, SubstitutionSauce, SubstitutionDrinks}, context)
+}
+
+// IngredientSubstitution — чем можно заменить ингредиент IngredientID
+type IngredientSubstitution struct {
+	ID             string    `db:"id"`
+	IngredientID   string    `db:"ingredient_id"`
+	IngredientName string    `db:"ingredient_name"`
+	Context        string    `db:"context"`
+	Bidirectional  bool      `db:"bidirectional"` // замена работает и в обратную сторону
+	Notes          string    `db:"notes"`
+	CreatedAt      time.Time `db:"created_at"`
+	Parts          []SubstitutionPart
+}
+
+// SubstitutionPart — заменитель и его количество на единицу исходного ингредиента
+type SubstitutionPart struct {
+	SubstitutionID string  `db:"substitution_id"`
+	IngredientID   string  `db:"ingredient_id"`
+	Name           string  `db:"name"`
+	Ratio          float64 `db:"ratio"`
+}
+
+// RecipeSubstitutions — варианты замены одного ингредиента рецепта
+type RecipeSubstitutions struct {
+	Ingredient IngredientWithAmount
+	Options    []SubstitutionOption
+}
+
+// SubstitutionOption — замена с количествами, пересчитанными на количество в рецепте
+type SubstitutionOption struct {
+	SubstitutionID string
+	Context        string
+	Notes          string
+	Parts          []SubstitutionAmount
+	Available      bool // все заменители есть у пользователя
+}
+
+type SubstitutionAmount struct {
+	IngredientID string
+	Name         string
+	Amount       float64
+	Unit         string
+	InPantry     bool
+}
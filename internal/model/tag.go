@@ -0,0 +1,21 @@
+package model
+
+import "slices"
+
+// Виды тегов
+const (
+	TagCuisine  = "cuisine"
+	TagMealType = "meal_type"
+	TagOccasion = "occasion"
+	TagOther    = "other"
+)
+
+type Tag struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
+	Kind string `db:"kind"`
+}
+
+func IsValidTagKind(kind string) bool {
+	return slices.Contains([]string{TagCuisine, TagMealType, TagOccasion, TagOther}, kind)
+}
@@ -0,0 +1,26 @@
+package model
+
+const (
+	DimensionMass   = "mass"
+	DimensionVolume = "volume"
+	DimensionCount  = "count"
+)
+
+const (
+	SystemMetric   = "metric"
+	SystemImperial = "imperial"
+	SystemAny      = "any" // штуки одинаковы в любой системе
+)
+
+type Unit struct {
+	Code      string   `db:"code"`
+	Name      string   `db:"name"`
+	Dimension string   `db:"dimension"`
+	System    string   `db:"system"`
+	ToBase    float64  `db:"to_base"` // множитель к g, ml или pcs
+	Aliases   []string `db:"-"`
+}
+
+func IsValidUnitSystem(system string) bool {
+	return system == SystemMetric || system == SystemImperial
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+const (
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleUser   = "user"
+)
+
+type User struct {
+	ID           string    `db:"id"`
+	Email        string    `db:"email"`
+	PasswordHash string    `db:"password_hash"`
+	Role         string    `db:"role"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// Principal — аутентифицированный пользователь текущего запроса
+type Principal struct {
+	UserID string
+	Role   string
+}
+
+func (p Principal) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidRole(role string) bool {
+	return role == RoleAdmin || role == RoleEditor || role == RoleUser
+}
@@ -22,9 +22,9 @@ func NewCategoryRepository(db *sqlx.DB) *CategoryRepository {
 
 func (it *CategoryRepository) Create(ctx context.Context, category *model.Category) error {
 	query, args, err := it.sb.Insert("recipe_categories").
-		Columns("id", "name", "image_url").
-		Values(category.ID, category.Name, category.ImageUrl).
-		Suffix("ON CONFLICT (name) DO UPDATE SET image_url = EXCLUDED.image_url").
+		Columns("id", "name", "image_url", "image_file_id").
+		Values(category.ID, category.Name, category.ImageUrl, nullIfEmpty(category.ImageFileID)).
+		Suffix("ON CONFLICT (name) DO UPDATE SET image_url = EXCLUDED.image_url, image_file_id = EXCLUDED.image_file_id").
 		ToSql()
 	if err != nil {
 		return err
@@ -34,23 +34,38 @@ func (it *CategoryRepository) Create(ctx context.Context, category *model.Catego
 	return err
 }
 
-func (it *CategoryRepository) GetAll(ctx context.Context) ([]model.Category, error) {
-	query, args, err := it.sb.Select("*").
-		From("recipe_categories").
-		OrderBy("name").
-		ToSql()
+func (it *CategoryRepository) selectCategory() squirrel.SelectBuilder {
+	return it.sb.
+		Select("id", "name", "COALESCE(image_url, '') AS image_url", "COALESCE(image_file_id, '') AS image_file_id").
+		From("recipe_categories")
+}
+
+var categorySortFields = sortFields[model.Category]{
+	"created_at": {column: "id", value: func(c model.Category) any { return c.ID }},
+	"name":       {column: "name", value: func(c model.Category) any { return c.Name }},
+}
+
+func (it *CategoryRepository) GetAll(ctx context.Context, page model.PageRequest) (model.Page[model.Category], error) {
+	builder, field, err := paginate(it.selectCategory(), page, categorySortFields, "id")
 	if err != nil {
-		return nil, err
+		return model.Page[model.Category]{}, err
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return model.Page[model.Category]{}, err
 	}
 
 	var categories []model.Category
-	err = it.db.SelectContext(ctx, &categories, query, args...)
-	return categories, err
+	if err := it.db.SelectContext(ctx, &categories, query, args...); err != nil {
+		return model.Page[model.Category]{}, err
+	}
+
+	return nextPage(categories, page, field, func(c model.Category) string { return c.ID })
 }
 
 func (it *CategoryRepository) GetByID(ctx context.Context, id string) (*model.Category, error) {
-	query, args, err := it.sb.Select("*").
-		From("recipe_categories").
+	query, args, err := it.selectCategory().
 		Where(squirrel.Eq{"id": id}).
 		ToSql()
 	if err != nil {
@@ -64,22 +79,45 @@ func (it *CategoryRepository) GetByID(ctx context.Context, id string) (*model.Ca
 	return &category, nil
 }
 
+// Delete удаляет категорию вместе со связями с рецептами, но не сами рецепты. У рецептов, для которых
+// она была основной, основной становится следующая по порядку категория, а если её нет — NULL.
 func (it *CategoryRepository) Delete(ctx context.Context, id string) error {
+	tx, err := it.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query, args, err := it.sb.
+		Update("recipes r").
+		Set("category_id", squirrel.Expr("(SELECT l.category_id FROM recipe_category_links l WHERE l.recipe_id = r.id AND l.category_id <> ? ORDER BY l.position, l.category_id LIMIT 1)", id)).
+		Where(squirrel.Eq{"r.category_id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	query, args, err = it.sb.
 		Delete("recipe_categories").
 		Where(squirrel.Eq{"id": id}).
 		ToSql()
 	if err != nil {
 		return err
 	}
-	_, err = it.db.ExecContext(ctx, query, args...)
-	return err
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (it *CategoryRepository) Update(ctx context.Context, category *model.Category) error {
-	query, args, err := it.sb.Update("ingredients").
+	query, args, err := it.sb.Update("recipe_categories").
 		Set("name", category.Name).
 		Set("image_url", category.ImageUrl).
+		Set("image_file_id", nullIfEmpty(category.ImageFileID)).
 		Where(squirrel.Eq{"id": category.ID}).
 		ToSql()
 	if err != nil {
@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+	"net/http"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type CollectionRepository struct {
+	db *sqlx.DB
+	sq squirrel.StatementBuilderType
+}
+
+func NewCollectionRepository(db *sqlx.DB) *CollectionRepository {
+	return &CollectionRepository{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (it *CollectionRepository) Create(ctx context.Context, collection *model.Collection) error {
+	query, args, err := it.sq.Insert("collections").
+		Columns("id", "user_id", "name", "created_at").
+		Values(collection.ID, collection.UserID, collection.Name, collection.CreatedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	if isUniqueViolation(err) {
+		return puberr.ErrExists.SetHTTPCode(http.StatusConflict).SetCause(err)
+	}
+	return err
+}
+
+func (it *CollectionRepository) selectCollection() squirrel.SelectBuilder {
+	return it.sq.
+		Select("col.id", "col.user_id", "col.name", "col.created_at").
+		Column("(SELECT COUNT(*) FROM collection_recipes cr WHERE cr.collection_id = col.id) AS recipe_count").
+		From("collections col")
+}
+
+func (it *CollectionRepository) GetByID(ctx context.Context, id string) (*model.Collection, error) {
+	query, args, err := it.selectCollection().Where(squirrel.Eq{"col.id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var collection model.Collection
+	if err := it.db.GetContext(ctx, &collection, query, args...); err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (it *CollectionRepository) GetByUserID(ctx context.Context, userID string) ([]model.Collection, error) {
+	query, args, err := it.selectCollection().
+		Where(squirrel.Eq{"col.user_id": userID}).
+		OrderBy("col.name").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]model.Collection, 0)
+	err = it.db.SelectContext(ctx, &collections, query, args...)
+	return collections, err
+}
+
+func (it *CollectionRepository) Rename(ctx context.Context, id, name string) error {
+	query, args, err := it.sq.Update("collections").
+		Set("name", name).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	if isUniqueViolation(err) {
+		return puberr.ErrExists.SetHTTPCode(http.StatusConflict).SetCause(err)
+	}
+	return err
+}
+
+func (it *CollectionRepository) Delete(ctx context.Context, id string) error {
+	query, args, err := it.sq.Delete("collections").Where(squirrel.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// RecipeIDs возвращает рецепты коллекции в пользовательском порядке
+func (it *CollectionRepository) RecipeIDs(ctx context.Context, collectionID string) ([]string, error) {
+	query, args, err := it.sq.Select("recipe_id").
+		From("collection_recipes").
+		Where(squirrel.Eq{"collection_id": collectionID}).
+		OrderBy("position", "added_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0)
+	err = it.db.SelectContext(ctx, &ids, query, args...)
+	return ids, err
+}
+
+// AddRecipe добавляет рецепт в конец коллекции; повторное добавление ничего не меняет
+func (it *CollectionRepository) AddRecipe(ctx context.Context, collectionID, recipeID string) error {
+	// вложенный запрос собирается без $-плейсхолдеров, их нумерует внешний
+	position := squirrel.Select("COALESCE(MAX(position) + 1, 0)").
+		From("collection_recipes").
+		Where(squirrel.Eq{"collection_id": collectionID})
+
+	query, args, err := it.sq.Insert("collection_recipes").
+		Columns("collection_id", "recipe_id", "position").
+		Values(collectionID, recipeID, squirrel.Expr("(?)", position)).
+		Suffix("ON CONFLICT (collection_id, recipe_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.ErrResourceNotFound.SetCause(err)
+	}
+	return err
+}
+
+func (it *CollectionRepository) RemoveRecipe(ctx context.Context, collectionID, recipeID string) error {
+	query, args, err := it.sq.Delete("collection_recipes").
+		Where(squirrel.Eq{"collection_id": collectionID, "recipe_id": recipeID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// Reorder выставляет позиции рецептов по порядку recipeIDs одним запросом
+func (it *CollectionRepository) Reorder(ctx context.Context, collectionID string, recipeIDs []string) error {
+	ids := pq.Array(recipeIDs)
+
+	query, args, err := it.sq.Update("collection_recipes").
+		Set("position", squirrel.Expr("array_position(?::varchar[], recipe_id) - 1", ids)).
+		Where(squirrel.Eq{"collection_id": collectionID}).
+		Where("recipe_id = ANY(?)", ids).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
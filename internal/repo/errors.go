@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+)
+
+func isPQError(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
+
+func isUniqueViolation(err error) bool {
+	return isPQError(err, pqUniqueViolation)
+}
+
+func isForeignKeyViolation(err error) bool {
+	return isPQError(err, pqForeignKeyViolation)
+}
@@ -2,7 +2,11 @@ package repo
 
 import (
 	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
 	"context"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
@@ -22,9 +26,9 @@ func NewFileRepository(db *sqlx.DB) *FileRepository {
 
 func (r *FileRepository) Create(ctx context.Context, file *model.File) error {
 	query, args, err := r.sb.Insert("files").
-		Columns("id", "name", "path").
-		Values(file.ID, file.Name, file.Path).
-		Suffix("ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, path = EXCLUDED.path").
+		Columns("id", "name", "path", "mime_type", "width", "height", "renditions", "created_at").
+		Values(file.ID, file.Name, file.Path, file.MimeType, file.Width, file.Height, file.Renditions, file.CreatedAt).
+		Suffix("ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, path = EXCLUDED.path, mime_type = EXCLUDED.mime_type, width = EXCLUDED.width, height = EXCLUDED.height, renditions = EXCLUDED.renditions").
 		ToSql()
 	if err != nil {
 		return err
@@ -33,24 +37,52 @@ func (r *FileRepository) Create(ctx context.Context, file *model.File) error {
 	return err
 }
 
-func (r *FileRepository) GetAll(ctx context.Context) ([]model.File, error) {
+var fileSortFields = sortFields[model.File]{
+	"created_at": {column: "id", value: func(f model.File) any { return f.ID }},
+	"name":       {column: "name", value: func(f model.File) any { return f.Name }},
+}
+
+func (r *FileRepository) GetAll(ctx context.Context, page model.PageRequest) (model.Page[model.File], error) {
+	builder, field, err := paginate(r.sb.Select("*").From("files"), page, fileSortFields, "id")
+	if err != nil {
+		return model.Page[model.File]{}, err
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return model.Page[model.File]{}, err
+	}
+
+	files := make([]model.File, 0, 0)
+	if err := r.db.SelectContext(ctx, &files, query, args...); err != nil {
+		return model.Page[model.File]{}, err
+	}
+
+	return nextPage(files, page, field, func(f model.File) string { return f.ID })
+}
+
+func (r *FileRepository) GetByID(ctx context.Context, id string) (*model.File, error) {
 	query, args, err := r.sb.Select("*").
 		From("files").
-		OrderBy("name").
+		Where(squirrel.Eq{"id": id}).
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	files := make([]model.File, 0, 0)
-	err = r.db.SelectContext(ctx, &files, query, args...)
-	return files, err
+	var file model.File
+	if err := r.db.GetContext(ctx, &file, query, args...); err != nil {
+		return nil, err
+	}
+	return &file, nil
 }
 
-func (r *FileRepository) GetByID(ctx context.Context, id string) (*model.File, error) {
+// GetByPath ищет файл по публичному URL, которым раньше ссылались на картинки
+func (r *FileRepository) GetByPath(ctx context.Context, path string) (*model.File, error) {
 	query, args, err := r.sb.Select("*").
 		From("files").
-		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"path": path}).
+		Limit(1).
 		ToSql()
 	if err != nil {
 		return nil, err
@@ -63,6 +95,55 @@ func (r *FileRepository) GetByID(ctx context.Context, id string) (*model.File, e
 	return &file, nil
 }
 
+// GetUnreferenced возвращает файлы, созданные раньше before, на которые не ссылается ни одна сущность.
+// Ссылка по старому image_url тоже считается, даже если image_file_id не заполнен.
+func (r *FileRepository) GetUnreferenced(ctx context.Context, before time.Time) ([]model.File, error) {
+	builder := r.sb.Select("f.*").
+		From("files f").
+		Where(squirrel.Lt{"f.created_at": before})
+	for _, table := range fileReferences {
+		builder = builder.Where("NOT EXISTS (SELECT 1 FROM " + table + " t WHERE t.image_file_id = f.id)")
+	}
+	for _, table := range fileURLReferences {
+		builder = builder.Where("NOT EXISTS (SELECT 1 FROM " + table + " t WHERE t.image_url = f.path)")
+	}
+
+	query, args, err := builder.OrderBy("f.created_at").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]model.File, 0)
+	err = r.db.SelectContext(ctx, &files, query, args...)
+	return files, err
+}
+
+// GetAllObjects возвращает пути и варианты всех файлов, чтобы найти объекты хранилища без записи в files
+func (r *FileRepository) GetAllObjects(ctx context.Context) ([]model.File, error) {
+	query, args, err := r.sb.Select("id", "path", "renditions").From("files").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]model.File, 0)
+	err = r.db.SelectContext(ctx, &files, query, args...)
+	return files, err
+}
+
+// GetReferencedURLs возвращает image_url всех сущностей из fileURLReferences: по ним старые картинки
+// ссылаются на объекты хранилища без записи в files
+func (r *FileRepository) GetReferencedURLs(ctx context.Context) ([]string, error) {
+	parts := make([]string, 0, len(fileURLReferences))
+	for _, table := range fileURLReferences {
+		parts = append(parts, "SELECT image_url FROM "+table+" WHERE image_url IS NOT NULL AND image_url <> ''")
+	}
+
+	urls := make([]string, 0)
+	err := r.db.SelectContext(ctx, &urls, strings.Join(parts, " UNION "))
+	return urls, err
+}
+
+// Delete не удаляет файл, пока на него ссылаются рецепты, шаги, ингредиенты или категории
 func (r *FileRepository) Delete(ctx context.Context, id string) error {
 	query, args, err := r.sb.
 		Delete("files").
@@ -72,5 +153,14 @@ func (r *FileRepository) Delete(ctx context.Context, id string) error {
 		return err
 	}
 	_, err = r.db.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.NewPubErr("file is still used by a recipe, step, ingredient or category").SetHTTPCode(http.StatusConflict).SetCause(err)
+	}
 	return err
 }
+
+// fileReferences — таблицы с колонкой image_file_id
+var fileReferences = []string{"recipes", "recipe_steps", "ingredients", "recipe_categories"}
+
+// fileURLReferences — таблицы, где картинка ещё может быть указана только URL
+var fileURLReferences = []string{"recipes", "ingredients", "recipe_categories"}
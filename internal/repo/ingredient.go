@@ -3,11 +3,14 @@ package repo
 import (
 	"CookFinder.Backend/internal/model"
 	"context"
+	"strings"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type IngredientRepository struct {
 	db *sqlx.DB
 	sb squirrel.StatementBuilderType
@@ -22,8 +25,11 @@ func NewIngredientRepository(db *sqlx.DB) *IngredientRepository {
 
 func (it *IngredientRepository) Create(ctx context.Context, ingredient *model.Ingredient) error {
 	query, args, err := it.sb.Insert("ingredients").
-		Columns("id", "name", "image_url").
-		Values(ingredient.ID, ingredient.Name, ingredient.ImageUrl).
+		Columns("id", "name", "image_url", "image_file_id", "density_g_per_ml", "unit_weight_g", "allergens", "is_animal", "is_meat", "allergens_reviewed", "aisle",
+			"kcal_per_100g", "protein_per_100g", "fat_per_100g", "carbs_per_100g", "fiber_per_100g", "sugar_per_100g", "salt_per_100g").
+		Values(ingredient.ID, ingredient.Name, ingredient.ImageUrl, nullIfEmpty(ingredient.ImageFileID), ingredient.DensityGPerMl, ingredient.UnitWeightG,
+			stringArray(ingredient.Allergens), ingredient.IsAnimal, ingredient.IsMeat, ingredient.AllergensReviewed, ingredient.Aisle,
+			ingredient.Kcal, ingredient.Protein, ingredient.Fat, ingredient.Carbs, ingredient.Fiber, ingredient.Sugar, ingredient.Salt).
 		Suffix("ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name").
 		ToSql()
 	if err != nil {
@@ -34,7 +40,7 @@ func (it *IngredientRepository) Create(ctx context.Context, ingredient *model.In
 }
 
 func (it *IngredientRepository) GetByID(ctx context.Context, id string) (*model.Ingredient, error) {
-	query, args, err := it.sb.Select("*").From("ingredients").Where(squirrel.Eq{"id": id}).ToSql()
+	query, args, err := it.selectIngredient().Where(squirrel.Eq{"id": id}).ToSql()
 	if err != nil {
 		return nil, err
 	}
@@ -45,9 +51,20 @@ func (it *IngredientRepository) GetByID(ctx context.Context, id string) (*model.
 	return &ingredient, nil
 }
 
+func (it *IngredientRepository) GetByIDs(ctx context.Context, ids []string) ([]model.Ingredient, error) {
+	query, args, err := it.selectIngredient().
+		Where(squirrel.Eq{"id": ids}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var ingredients []model.Ingredient
+	err = it.db.SelectContext(ctx, &ingredients, query, args...)
+	return ingredients, err
+}
+
 func (it *IngredientRepository) GetAllByID(ctx context.Context, id string) ([]model.Ingredient, error) {
-	query, args, err := it.sb.Select("*").
-		From("ingredients").
+	query, args, err := it.selectIngredient().
 		Where(squirrel.Eq{"id": id}).
 		ToSql()
 	if err != nil {
@@ -62,6 +79,21 @@ func (it *IngredientRepository) Update(ctx context.Context, ingredient *model.In
 	query, args, err := it.sb.Update("ingredients").
 		Set("name", ingredient.Name).
 		Set("image_url", ingredient.ImageUrl).
+		Set("image_file_id", nullIfEmpty(ingredient.ImageFileID)).
+		Set("density_g_per_ml", ingredient.DensityGPerMl).
+		Set("unit_weight_g", ingredient.UnitWeightG).
+		Set("allergens", stringArray(ingredient.Allergens)).
+		Set("is_animal", ingredient.IsAnimal).
+		Set("is_meat", ingredient.IsMeat).
+		Set("allergens_reviewed", ingredient.AllergensReviewed).
+		Set("aisle", ingredient.Aisle).
+		Set("kcal_per_100g", ingredient.Kcal).
+		Set("protein_per_100g", ingredient.Protein).
+		Set("fat_per_100g", ingredient.Fat).
+		Set("carbs_per_100g", ingredient.Carbs).
+		Set("fiber_per_100g", ingredient.Fiber).
+		Set("sugar_per_100g", ingredient.Sugar).
+		Set("salt_per_100g", ingredient.Salt).
 		Where(squirrel.Eq{"id": ingredient.ID}).
 		ToSql()
 	if err != nil {
@@ -71,17 +103,62 @@ func (it *IngredientRepository) Update(ctx context.Context, ingredient *model.In
 	return err
 }
 
-func (it *IngredientRepository) GetAll(ctx context.Context) ([]model.Ingredient, error) {
-	query, args, err := it.sb.Select("*").
+// selectIngredient перечисляет колонки явно: image_url и image_file_id могут быть NULL
+func (it *IngredientRepository) selectIngredient() squirrel.SelectBuilder {
+	return it.sb.Select(
+		"id", "name", "COALESCE(image_url, '') AS image_url", "COALESCE(image_file_id, '') AS image_file_id",
+		"density_g_per_ml", "unit_weight_g", "allergens", "is_animal", "is_meat", "allergens_reviewed", "aisle",
+		"kcal_per_100g", "protein_per_100g", "fat_per_100g", "carbs_per_100g", "fiber_per_100g", "sugar_per_100g", "salt_per_100g",
+	).From("ingredients")
+}
+
+// id — UUIDv7, поэтому сортировка по нему совпадает с порядком создания
+var ingredientSortFields = sortFields[model.Ingredient]{
+	"created_at": {column: "id", value: func(i model.Ingredient) any { return i.ID }},
+	"name":       {column: "name", value: func(i model.Ingredient) any { return i.Name }},
+}
+
+func (it *IngredientRepository) GetAll(ctx context.Context, page model.PageRequest) (model.Page[model.Ingredient], error) {
+	builder, field, err := paginate(it.selectIngredient(), page, ingredientSortFields, "id")
+	if err != nil {
+		return model.Page[model.Ingredient]{}, err
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return model.Page[model.Ingredient]{}, err
+	}
+	var ingredients []model.Ingredient
+	if err := it.db.SelectContext(ctx, &ingredients, query, args...); err != nil {
+		return model.Page[model.Ingredient]{}, err
+	}
+
+	return nextPage(ingredients, page, field, func(i model.Ingredient) string { return i.ID })
+}
+
+// Suggest ищет ингредиенты по триграммному сходству с q (устойчиво к опечаткам).
+// Совпадения по префиксу идут первыми, чтобы работало автодополнение во время ввода.
+func (it *IngredientRepository) Suggest(ctx context.Context, q string, limit int) ([]model.IngredientSuggestion, error) {
+	prefix := likeEscaper.Replace(q) + "%"
+
+	query, args, err := it.sb.Select("id", "name", "COALESCE(image_url, '') AS image_url").
+		Column(squirrel.Expr("similarity(name, ?) AS score", q)).
+		Column(squirrel.Expr("name ILIKE ? AS is_prefix", prefix)).
 		From("ingredients").
-		OrderBy("name").
+		Where(squirrel.Or{
+			squirrel.Expr("name % ?", q),
+			squirrel.Expr("name ILIKE ?", prefix),
+		}).
+		OrderBy("is_prefix DESC", "score DESC", "name").
+		Limit(uint64(limit)).
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
-	var ingredients []model.Ingredient
-	err = it.db.SelectContext(ctx, &ingredients, query, args...)
-	return ingredients, err
+
+	suggestions := make([]model.IngredientSuggestion, 0, limit)
+	err = it.db.SelectContext(ctx, &suggestions, query, args...)
+	return suggestions, err
 }
 
 func (it *IngredientRepository) Delete(ctx context.Context, id string) error {
@@ -0,0 +1,163 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type MealPlanRepository struct {
+	db *sqlx.DB
+	sq squirrel.StatementBuilderType
+}
+
+func NewMealPlanRepository(db *sqlx.DB) *MealPlanRepository {
+	return &MealPlanRepository{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// dateOnly передаёт дату строкой: time.Time ушёл бы как timestamptz, и приведение к DATE зависело бы от часового пояса сессии
+func dateOnly(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+func (it *MealPlanRepository) Create(ctx context.Context, entry *model.MealPlanEntry) error {
+	query, args, err := it.sq.Insert("meal_plan_entries").
+		Columns("id", "user_id", "date", "slot", "recipe_id", "servings", "created_at").
+		Values(entry.ID, entry.UserID, dateOnly(entry.Date), entry.Slot, entry.RecipeID, entry.Servings, entry.CreatedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.ErrResourceNotFound.SetCause(err)
+	}
+	return err
+}
+
+// ReplaceSlots заменяет записи пользователя в slots на днях с from по to (не включая to) на entries
+// в одной транзакции, например сгенерированным планом: повторное сохранение не удваивает приёмы пищи
+func (it *MealPlanRepository) ReplaceSlots(ctx context.Context, userID string, from, to time.Time, slots []string, entries []model.MealPlanEntry) error {
+	tx, err := it.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := it.sq.Delete("meal_plan_entries").
+		Where(squirrel.Eq{"user_id": userID, "slot": slots}).
+		Where("date >= ? AND date < ?", dateOnly(from), dateOnly(to)).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if len(entries) > 0 {
+		builder := it.sq.Insert("meal_plan_entries").
+			Columns("id", "user_id", "date", "slot", "recipe_id", "servings", "created_at")
+		for _, entry := range entries {
+			builder = builder.Values(entry.ID, entry.UserID, dateOnly(entry.Date), entry.Slot, entry.RecipeID, entry.Servings, entry.CreatedAt)
+		}
+
+		query, args, err = builder.ToSql()
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, query, args...)
+		if isForeignKeyViolation(err) {
+			return puberr.ErrResourceNotFound.SetCause(err)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (it *MealPlanRepository) Update(ctx context.Context, entry *model.MealPlanEntry) error {
+	query, args, err := it.sq.Update("meal_plan_entries").
+		Set("date", dateOnly(entry.Date)).
+		Set("slot", entry.Slot).
+		Set("recipe_id", entry.RecipeID).
+		Set("servings", entry.Servings).
+		Where(squirrel.Eq{"id": entry.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.ErrResourceNotFound.SetCause(err)
+	}
+	return err
+}
+
+func (it *MealPlanRepository) Delete(ctx context.Context, id string) error {
+	query, args, err := it.sq.Delete("meal_plan_entries").Where(squirrel.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// selectEntry пересчитывает пищевую ценность рецепта (она хранится на recipes.servings порций) на порции записи
+func (it *MealPlanRepository) selectEntry() squirrel.SelectBuilder {
+	const factor = "e.servings::DOUBLE PRECISION / GREATEST(r.servings, 1)"
+	return it.sq.
+		Select("e.id", "e.user_id", "e.date", "e.slot", "e.recipe_id", "e.servings", "e.created_at",
+			"r.title AS recipe_title", "COALESCE(r.image_url, '') AS recipe_image_url",
+			"r.energy * "+factor+" AS energy",
+			"r.fat * "+factor+" AS fat",
+			"r.protein * "+factor+" AS protein",
+			"r.carbs * "+factor+" AS carbs",
+			"r.fiber * "+factor+" AS fiber",
+			"r.sugar * "+factor+" AS sugar",
+			"r.sodium * "+factor+" AS sodium",
+		).
+		From("meal_plan_entries e").
+		Join("recipes r ON r.id = e.recipe_id")
+}
+
+func (it *MealPlanRepository) GetByID(ctx context.Context, id string) (*model.MealPlanEntry, error) {
+	query, args, err := it.selectEntry().Where(squirrel.Eq{"e.id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var entry model.MealPlanEntry
+	if err := it.db.GetContext(ctx, &entry, query, args...); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetByUserID возвращает записи пользователя с from по to включительно
+func (it *MealPlanRepository) GetByUserID(ctx context.Context, userID string, from, to time.Time) ([]model.MealPlanEntry, error) {
+	query, args, err := it.selectEntry().
+		Where(squirrel.Eq{"e.user_id": userID}).
+		Where(squirrel.GtOrEq{"e.date": dateOnly(from)}).
+		Where(squirrel.LtOrEq{"e.date": dateOnly(to)}).
+		OrderBy("e.date", "e.created_at", "e.id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]model.MealPlanEntry, 0)
+	err = it.db.SelectContext(ctx, &entries, query, args...)
+	return entries, err
+}
@@ -0,0 +1,114 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// sortField описывает разрешённое поле сортировки: SQL-выражение и значение этого выражения у элемента выборки
+type sortField[T any] struct {
+	column string
+	value  func(T) any
+}
+
+type sortFields[T any] map[string]sortField[T]
+
+type cursor struct {
+	Value any    `json:"v"`
+	ID    string `json:"id"`
+}
+
+// paginate добавляет к запросу keyset-пагинацию по (поле сортировки, id).
+// id — UUIDv7, поэтому он упорядочен по времени создания и однозначно разрешает равные значения сортировки.
+// Запрашивается на одну строку больше лимита, чтобы понять, есть ли следующая страница.
+func paginate[T any](builder squirrel.SelectBuilder, page model.PageRequest, fields sortFields[T], idColumn string) (squirrel.SelectBuilder, sortField[T], error) {
+	field, ok := fields[page.Sort]
+	if !ok {
+		return builder, field, puberr.NewPubErr(fmt.Sprintf("unsupported sort field %q", page.Sort))
+	}
+
+	direction, cmp := "ASC", ">"
+	if page.Desc {
+		direction, cmp = "DESC", "<"
+	}
+
+	if page.Cursor != "" {
+		c, err := decodeCursor(page.Cursor)
+		if err != nil {
+			return builder, field, err
+		}
+		builder = builder.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", field.column, idColumn, cmp), c.Value, c.ID)
+	}
+
+	return builder.
+		OrderBy(field.column+" "+direction, idColumn+" "+direction).
+		Limit(uint64(pageLimit(page) + 1)), field, nil
+}
+
+// nextPage обрезает лишнюю строку, запрошенную paginate, и строит курсор по последнему элементу страницы
+func nextPage[T any](items []T, page model.PageRequest, field sortField[T], id func(T) string) (model.Page[T], error) {
+	limit := pageLimit(page)
+	if len(items) <= limit {
+		if items == nil {
+			items = []T{}
+		}
+		return model.Page[T]{Items: items}, nil
+	}
+
+	items = items[:limit]
+	last := items[limit-1]
+
+	next, err := encodeCursor(cursor{Value: field.value(last), ID: id(last)})
+	if err != nil {
+		return model.Page[T]{}, err
+	}
+
+	return model.Page[T]{Items: items, NextCursor: next}, nil
+}
+
+func pageLimit(page model.PageRequest) int {
+	switch {
+	case page.Limit <= 0:
+		return DefaultPageLimit
+	case page.Limit > MaxPageLimit:
+		return MaxPageLimit
+	default:
+		return page.Limit
+	}
+}
+
+func encodeCursor(c cursor) (string, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeCursor(value string) (cursor, error) {
+	var c cursor
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return c, puberr.NewPubErr("invalid cursor").SetCause(err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&c); err != nil || c.ID == "" {
+		return c, puberr.NewPubErr("invalid cursor")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type PantryRepository struct {
+	db *sqlx.DB
+	sq squirrel.StatementBuilderType
+}
+
+func NewPantryRepository(db *sqlx.DB) *PantryRepository {
+	return &PantryRepository{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// expiresOn передаёт срок годности строкой, как dateOnly; nil остаётся NULL
+func expiresOn(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return dateOnly(*t)
+}
+
+func (it *PantryRepository) Create(ctx context.Context, item *model.PantryItem) error {
+	query, args, err := it.sq.Insert("pantry_items").
+		Columns("id", "user_id", "ingredient_id", "quantity", "unit", "expires_on", "created_at").
+		Values(item.ID, item.UserID, item.IngredientID, item.Quantity, item.Unit, expiresOn(item.ExpiresOn), item.CreatedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.ErrResourceNotFound.SetCause(err)
+	}
+	return err
+}
+
+func (it *PantryRepository) Update(ctx context.Context, item *model.PantryItem) error {
+	query, args, err := it.sq.Update("pantry_items").
+		Set("ingredient_id", item.IngredientID).
+		Set("quantity", item.Quantity).
+		Set("unit", item.Unit).
+		Set("expires_on", expiresOn(item.ExpiresOn)).
+		Where(squirrel.Eq{"id": item.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.ErrResourceNotFound.SetCause(err)
+	}
+	return err
+}
+
+func (it *PantryRepository) Delete(ctx context.Context, id string) error {
+	query, args, err := it.sq.Delete("pantry_items").Where(squirrel.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (it *PantryRepository) selectItem() squirrel.SelectBuilder {
+	return it.sq.
+		Select("p.id", "p.user_id", "p.ingredient_id", "i.name", "COALESCE(i.image_url, '') AS image_url",
+			"p.quantity", "p.unit", "p.expires_on", "p.created_at").
+		From("pantry_items p").
+		Join("ingredients i ON i.id = p.ingredient_id")
+}
+
+func (it *PantryRepository) GetByID(ctx context.Context, id string) (*model.PantryItem, error) {
+	query, args, err := it.selectItem().Where(squirrel.Eq{"p.id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var item model.PantryItem
+	if err := it.db.GetContext(ctx, &item, query, args...); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetByUserID возвращает запасы пользователя: сначала те, что испортятся раньше, без срока — в конце
+func (it *PantryRepository) GetByUserID(ctx context.Context, userID string) ([]model.PantryItem, error) {
+	query, args, err := it.selectItem().
+		Where(squirrel.Eq{"p.user_id": userID}).
+		OrderBy("p.expires_on NULLS LAST", "i.name", "p.id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]model.PantryItem, 0)
+	err = it.db.SelectContext(ctx, &items, query, args...)
+	return items, err
+}
+
+// GetIngredientIDs возвращает ингредиенты, которые есть у пользователя и ещё не испортились к today
+func (it *PantryRepository) GetIngredientIDs(ctx context.Context, userID string, today time.Time) ([]string, error) {
+	query, args, err := it.sq.
+		Select("DISTINCT ingredient_id").
+		From("pantry_items").
+		Where(squirrel.Eq{"user_id": userID}).
+		Where("(expires_on IS NULL OR expires_on >= ?)", dateOnly(today)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0)
+	err = it.db.SelectContext(ctx, &ids, query, args...)
+	return ids, err
+}
+
+// Consume блокирует партии ingredientIDs пользователя, ещё не испортившиеся к today, и передаёт их
+// в consume в порядке списания: сначала те, что испортятся раньше. Просроченные партии не списываются.
+// consume уменьшает Quantity на месте; изменённые партии сохраняются, закончившиеся удаляются.
+// Всё выполняется в одной транзакции, поэтому два одновременных списания не теряют друг друга.
+func (it *PantryRepository) Consume(ctx context.Context, userID string, ingredientIDs []string, today time.Time, consume func(items []model.PantryItem)) error {
+	tx, err := it.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := it.selectItem().
+		Where(squirrel.Eq{"p.user_id": userID}).
+		Where("p.ingredient_id = ANY(?)", pq.Array(ingredientIDs)).
+		Where("(p.expires_on IS NULL OR p.expires_on >= ?)", dateOnly(today)).
+		OrderBy("p.expires_on NULLS LAST", "p.created_at", "p.id").
+		Suffix("FOR UPDATE OF p").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	items := make([]model.PantryItem, 0)
+	if err := tx.SelectContext(ctx, &items, query, args...); err != nil {
+		return err
+	}
+
+	before := make([]float64, len(items))
+	for i, item := range items {
+		before[i] = item.Quantity
+	}
+	consume(items)
+
+	for i, item := range items {
+		if item.Quantity == before[i] {
+			continue
+		}
+
+		var builder squirrel.Sqlizer = it.sq.Update("pantry_items").
+			Set("quantity", item.Quantity).
+			Where(squirrel.Eq{"id": item.ID})
+		if item.Quantity <= 0 {
+			builder = it.sq.Delete("pantry_items").Where(squirrel.Eq{"id": item.ID})
+		}
+
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
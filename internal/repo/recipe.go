@@ -2,12 +2,17 @@ package repo
 
 import (
 	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
 	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type RecipeRepository struct {
@@ -29,8 +34,8 @@ func (it *RecipeRepository) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
 func (it *RecipeRepository) Create(ctx context.Context, recipe *model.Recipe) error {
 	query, args, err := it.sq.
 		Insert("recipes").
-		Columns("id", "title", "category_id", "prep_time_min", "cook_time_min", "method", "created_at", "image_url", "energy", "fat", "protein").
-		Values(recipe.ID, recipe.Title, recipe.CategoryID, recipe.PrepTimeMin, recipe.CookTimeMin, recipe.Method, recipe.CreatedAt, recipe.ImageURL, recipe.Energy, recipe.Fat, recipe.Protein).
+		Columns("id", "title", "category_id", "prep_time_min", "cook_time_min", "method", "created_at", "image_url", "energy", "fat", "protein", "carbs", "fiber", "sugar", "sodium", "servings", "nutrition_override", "nutrition_skipped", "diet_tags", "author_id", "image_file_id").
+		Values(recipe.ID, recipe.Title, recipe.CategoryID, recipe.PrepTimeMin, recipe.CookTimeMin, recipe.Method, recipe.CreatedAt, recipe.ImageURL, recipe.Energy, recipe.Fat, recipe.Protein, recipe.Carbs, recipe.Fiber, recipe.Sugar, recipe.Sodium, recipe.Servings, recipe.NutritionOverride, stringArray(recipe.NutritionSkipped), stringArray(recipe.DietTags), nullIfEmpty(recipe.AuthorID), nullIfEmpty(recipe.ImageFileID)).
 		ToSql()
 	if err != nil {
 		return err
@@ -42,8 +47,8 @@ func (it *RecipeRepository) Create(ctx context.Context, recipe *model.Recipe) er
 func (it *RecipeRepository) CreateWithTx(ctx context.Context, tx *sqlx.Tx, recipe *model.Recipe) error {
 	query, args, err := it.sq.
 		Insert("recipes").
-		Columns("id", "title", "category_id", "prep_time_min", "cook_time_min", "method", "created_at", "image_url", "energy", "fat", "protein").
-		Values(recipe.ID, recipe.Title, recipe.CategoryID, recipe.PrepTimeMin, recipe.CookTimeMin, recipe.Method, recipe.CreatedAt, recipe.ImageURL, recipe.Energy, recipe.Fat, recipe.Protein).
+		Columns("id", "title", "category_id", "prep_time_min", "cook_time_min", "method", "created_at", "image_url", "energy", "fat", "protein", "carbs", "fiber", "sugar", "sodium", "servings", "nutrition_override", "nutrition_skipped", "diet_tags", "author_id", "image_file_id").
+		Values(recipe.ID, recipe.Title, recipe.CategoryID, recipe.PrepTimeMin, recipe.CookTimeMin, recipe.Method, recipe.CreatedAt, recipe.ImageURL, recipe.Energy, recipe.Fat, recipe.Protein, recipe.Carbs, recipe.Fiber, recipe.Sugar, recipe.Sodium, recipe.Servings, recipe.NutritionOverride, stringArray(recipe.NutritionSkipped), stringArray(recipe.DietTags), nullIfEmpty(recipe.AuthorID), nullIfEmpty(recipe.ImageFileID)).
 		ToSql()
 	if err != nil {
 		return err
@@ -53,13 +58,7 @@ func (it *RecipeRepository) CreateWithTx(ctx context.Context, tx *sqlx.Tx, recip
 }
 
 func (it *RecipeRepository) GetByID(ctx context.Context, id string) (*model.RecipeCategoryIngredients, error) {
-	query, args, err := it.sq.
-		Select(
-			"it.id", "it.title", "it.category_id", "it.prep_time_min", "it.cook_time_min", "it.method", "it.created_at", "it.image_url", "it.energy", "it.fat", "it.protein",
-			"c.id AS category_id", "c.name AS category_name", "c.image_url AS category_image_url",
-		).
-		From("recipes it").
-		Join("recipe_categories c ON it.category_id = c.id").
+	query, args, err := it.selectRecipe().
 		Where(squirrel.Eq{"it.id": id}).
 		ToSql()
 	if err != nil {
@@ -81,6 +80,16 @@ func (it *RecipeRepository) GetByID(ctx context.Context, id string) (*model.Reci
 		return nil, err
 	}
 
+	categories, err := it.getCategoriesByRecipeIDs(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := it.getTagsByRecipeIDs(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
 	return &model.RecipeCategoryIngredients{
 		Recipe: row.Recipe,
 		Category: model.Category{
@@ -88,37 +97,336 @@ func (it *RecipeRepository) GetByID(ctx context.Context, id string) (*model.Reci
 			Name:     row.CategoryName,
 			ImageUrl: row.CategoryImageURL,
 		},
+		Categories:  nonNilCategories(categories[id]),
+		Tags:        nonNilTags(tags[id]),
 		Ingredients: ingredients,
+		Diet:        model.DietLabels(row.Recipe.DietTags, ingredients),
 	}, nil
 }
 
-func (it *RecipeRepository) GetAll(ctx context.Context, search, categoryID string) ([]model.RecipeCategoryIngredients, error) {
-	builder := it.sq.
-		Select(
-			"DISTINCT it.id", "it.title", "it.category_id", "it.prep_time_min", "it.cook_time_min", "it.method", "it.created_at", "it.image_url", "it.energy", "it.fat", "it.protein",
-			"c.id AS category_id", "c.name AS category_name", "c.image_url AS category_image_url",
-		).
-		From("recipes it").
-		Join("recipe_categories c ON it.category_id = c.id").
-		LeftJoin("recipe_ingredients ri ON ri.recipe_id = it.id").
-		LeftJoin("ingredients i ON i.id = ri.ingredient_id").
-		OrderBy("it.created_at DESC")
+var recipeSortFields = sortFields[model.RecipeCategoryIngredients]{
+	"created_at": {column: "it.created_at", value: func(r model.RecipeCategoryIngredients) any { return r.Recipe.CreatedAt }},
+	"title":      {column: "it.title", value: func(r model.RecipeCategoryIngredients) any { return r.Recipe.Title }},
+	"cook_time":  {column: "COALESCE(it.cook_time_min, 0)", value: func(r model.RecipeCategoryIngredients) any { return r.Recipe.CookTimeMin }},
+	"energy":     {column: "it.energy", value: func(r model.RecipeCategoryIngredients) any { return r.Recipe.Energy }},
+	"rating":     {column: "it.avg_rating", value: func(r model.RecipeCategoryIngredients) any { return r.Recipe.AvgRating }},
+}
+
+// recipeSearchSortFields доступны только вместе с полнотекстовым поиском, где в запросе есть q.query
+var recipeSearchSortFields = func() sortFields[model.RecipeCategoryIngredients] {
+	fields := sortFields[model.RecipeCategoryIngredients]{
+		"relevance": {column: recipeRankColumn, value: func(r model.RecipeCategoryIngredients) any { return r.Rank }},
+	}
+	for name, field := range recipeSortFields {
+		fields[name] = field
+	}
+	return fields
+}()
+
+const recipeRankColumn = "ts_rank_cd(it.search_vector, q.query)"
+
+func (it *RecipeRepository) GetAll(ctx context.Context, filter model.RecipeFilter, page model.PageRequest) (model.Page[model.RecipeCategoryIngredients], error) {
+	builder := it.selectRecipe()
+	fields := recipeSortFields
+
+	// Полнотекстовый поиск по названию, ингредиентам и способу приготовления
+	if filter.Search != "" {
+		builder = builder.
+			JoinClause("CROSS JOIN LATERAL (SELECT websearch_to_tsquery('russian', ?) || websearch_to_tsquery('english', ?) AS query) q", filter.Search, filter.Search).
+			Columns(
+				recipeRankColumn+" AS rank",
+				"ts_headline('russian', it.title, q.query, 'HighlightAll=true') AS title_highlight",
+				"ts_headline('russian', COALESCE(it.method, ''), q.query, 'MaxFragments=2, MaxWords=20, MinWords=5') AS method_highlight",
+			).
+			Where("it.search_vector @@ q.query")
+		fields = recipeSearchSortFields
+	}
+
+	builder = filterRecipes(builder, filter)
+
+	// Отметка «в избранном» для текущего пользователя
+	if filter.ViewerID != "" {
+		builder = builder.Column(
+			"EXISTS (SELECT 1 FROM collection_recipes cr JOIN collections col ON col.id = cr.collection_id WHERE col.user_id = ? AND cr.recipe_id = it.id) AS is_favorite",
+			filter.ViewerID,
+		)
+	}
+
+	builder, field, err := paginate(builder, page, fields, "it.id")
+	if err != nil {
+		return model.Page[model.RecipeCategoryIngredients]{}, err
+	}
+
+	recipes, err := it.selectRecipes(ctx, builder)
+	if err != nil {
+		return model.Page[model.RecipeCategoryIngredients]{}, err
+	}
+
+	return nextPage(recipes, page, field, func(r model.RecipeCategoryIngredients) string { return r.Recipe.ID })
+}
+
+// filterRecipes добавляет условия фильтра, кроме поиска и отметки избранного
+func filterRecipes(builder squirrel.SelectBuilder, filter model.RecipeFilter) squirrel.SelectBuilder {
+	// Фильтрация по категории: подходит любая из категорий рецепта, не только основная
+	if filter.CategoryID != "" {
+		builder = builder.Where("EXISTS (SELECT 1 FROM recipe_category_links rcl WHERE rcl.recipe_id = it.id AND rcl.category_id = ?)", filter.CategoryID)
+	}
 
-	// Фильтрация по названию рецепта и ингредиентам
-	if search != "" {
+	// Рецепт должен быть отмечен всеми перечисленными тегами
+	if len(filter.Tags) > 0 {
 		builder = builder.Where(
-			squirrel.Or{
-				squirrel.Expr("LOWER(it.title) LIKE LOWER(?)", "%"+search+"%"),
-				squirrel.Expr("LOWER(i.name) LIKE LOWER(?)", "%"+search+"%"),
-			},
+			"(SELECT COUNT(DISTINCT rt.tag_id) FROM recipe_tags rt WHERE rt.recipe_id = it.id AND rt.tag_id = ANY(?)) = ?",
+			pq.Array(filter.Tags), len(filter.Tags),
 		)
 	}
 
-	// Фильтрация по категории
-	if categoryID != "" {
-		builder = builder.Where("it.category_id = ?", categoryID)
+	if filter.AuthorID != "" {
+		builder = builder.Where("it.author_id = ?", filter.AuthorID)
+	}
+
+	// Диетические метки: выводимые проверяются по ингредиентам, остальные — по ручным меткам рецепта.
+	// Проверка по ингредиентам проходит только рецепты, где все ингредиенты проверены, как и в DietLabels.
+	derived := len(filter.ExcludeAllergens) > 0
+	for _, diet := range filter.Diet {
+		switch allergens, ok := model.DietAllergens[diet]; {
+		case diet == model.DietVegan:
+			builder = builder.Where(noRecipeIngredient("i.is_animal"))
+			derived = true
+		case diet == model.DietVegetarian:
+			builder = builder.Where(noRecipeIngredient("i.is_meat"))
+			derived = true
+		case ok:
+			builder = builder.Where(noRecipeIngredient("i.allergens && ?"), pq.Array(allergens))
+			derived = true
+		default:
+			builder = builder.Where("? = ANY(it.diet_tags)", diet)
+		}
+	}
+
+	if len(filter.ExcludeAllergens) > 0 {
+		builder = builder.Where(noRecipeIngredient("i.allergens && ?"), pq.Array(filter.ExcludeAllergens))
+	}
+	if derived {
+		builder = builder.
+			Where("EXISTS (SELECT 1 FROM recipe_ingredients ri WHERE ri.recipe_id = it.id)").
+			Where(noRecipeIngredient("NOT i.allergens_reviewed"))
+	}
+
+	if len(filter.ExcludeIngredients) > 0 {
+		builder = builder.Where(noRecipeIngredient("i.id = ANY(?)"), pq.Array(filter.ExcludeIngredients))
+	}
+
+	// Диапазоны пищевой ценности считаются на одну порцию
+	for _, nutrient := range model.RecipeNutrients {
+		perServing := "it." + nutrient + "::FLOAT / it.servings"
+		if v, ok := filter.MinNutrients[nutrient]; ok {
+			builder = builder.Where(perServing+" >= ?", v)
+		}
+		if v, ok := filter.MaxNutrients[nutrient]; ok {
+			builder = builder.Where(perServing+" <= ?", v)
+		}
+	}
+	return builder
+}
+
+// GetCandidates возвращает до limit рецептов, подходящих под фильтр, в порядке, зависящем от seed:
+// при большом каталоге каждый seed получает свою выборку, а не limit самых старых рецептов
+func (it *RecipeRepository) GetCandidates(ctx context.Context, filter model.RecipeFilter, seed int64, limit int) ([]model.RecipeCategoryIngredients, error) {
+	builder := filterRecipes(it.selectRecipe(), filter).
+		OrderByClause("md5(it.id::text || ?), it.id", strconv.FormatInt(seed, 10)).
+		Limit(uint64(limit))
+	return it.selectRecipes(ctx, builder)
+}
+
+// FindByPantry ранжирует рецепты по доле их recipe_ingredients, покрытых ингредиентами пользователя
+// или их заменами из SubstitutedIDs. Возвращает рецепты без Missing: недостающие ингредиенты вычисляет сервис.
+func (it *RecipeRepository) FindByPantry(ctx context.Context, search model.PantrySearch) ([]model.RecipeMatch, error) {
+	counts := it.sq.
+		Select("ri.recipe_id", "COUNT(*) AS total_count").
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE ri.ingredient_id = ANY(?) OR ri.ingredient_id = ANY(?)) AS matched_count",
+			pq.Array(search.IngredientIDs), pq.Array(search.SubstitutedIDs))).
+		From("recipe_ingredients ri").
+		GroupBy("ri.recipe_id")
+
+	if len(search.ExcludeIDs) > 0 {
+		counts = counts.Having("NOT bool_or(ri.ingredient_id = ANY(?))", pq.Array(search.ExcludeIDs))
+	}
+
+	builder := it.sq.
+		Select("m.recipe_id", "m.matched_count", "m.total_count", "m.matched_count::float8 / m.total_count AS coverage").
+		FromSelect(counts, "m").
+		Where("m.matched_count > 0").
+		Where("m.matched_count::float8 / m.total_count >= ?", search.MinCoverage).
+		OrderBy("coverage DESC", "m.matched_count DESC", "m.recipe_id")
+
+	if search.Limit > 0 {
+		builder = builder.Limit(uint64(search.Limit))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		RecipeID     string  `db:"recipe_id"`
+		MatchedCount int     `db:"matched_count"`
+		TotalCount   int     `db:"total_count"`
+		Coverage     float64 `db:"coverage"`
 	}
+	if err := it.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []model.RecipeMatch{}, nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.RecipeID
+	}
+
+	recipes, err := it.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.RecipeMatch, 0, len(rows))
+	for i, row := range rows {
+		if recipes[i] == nil {
+			continue
+		}
+		result = append(result, model.RecipeMatch{
+			RecipeCategoryIngredients: *recipes[i],
+			MatchedCount:              row.MatchedCount,
+			TotalCount:                row.TotalCount,
+			Coverage:                  row.Coverage,
+		})
+	}
+
+	return result, nil
+}
+
+// FindByExpiring возвращает рецепты, в которых используются запасы пользователя со сроком до search.Until:
+// сначала те, где запас испортится раньше всего, затем те, где таких запасов больше.
+// Missing и Expiring вычисляет сервис.
+func (it *RecipeRepository) FindByExpiring(ctx context.Context, search model.ExpiringSearch) ([]model.ExpiringMatch, error) {
+	until := dateOnly(search.Until)
+
+	// подзапрос собирается без Dollar: номера параметров расставит внешний запрос
+	pantry := squirrel.
+		Select("ingredient_id", "MIN(expires_on) AS expires_on").
+		From("pantry_items").
+		Where(squirrel.Eq{"user_id": search.UserID}).
+		Where("(expires_on IS NULL OR expires_on >= ?)", dateOnly(search.Today)).
+		GroupBy("ingredient_id")
+
+	builder := it.sq.
+		Select("ri.recipe_id", "COUNT(*) AS total_count", "COUNT(p.ingredient_id) AS matched_count",
+			"COUNT(p.ingredient_id)::float8 / COUNT(*) AS coverage").
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE p.expires_on <= ?) AS expiring_count", until)).
+		Column("MIN(p.expires_on) AS soonest_expiry").
+		From("recipe_ingredients ri").
+		JoinClause(pantry.Prefix("LEFT JOIN (").Suffix(") p ON p.ingredient_id = ri.ingredient_id")).
+		GroupBy("ri.recipe_id").
+		Having("COUNT(*) FILTER (WHERE p.expires_on <= ?) > 0", until).
+		OrderBy("soonest_expiry", "expiring_count DESC", "coverage DESC", "ri.recipe_id")
+
+	if search.Limit > 0 {
+		builder = builder.Limit(uint64(search.Limit))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		RecipeID      string    `db:"recipe_id"`
+		MatchedCount  int       `db:"matched_count"`
+		TotalCount    int       `db:"total_count"`
+		Coverage      float64   `db:"coverage"`
+		ExpiringCount int       `db:"expiring_count"`
+		SoonestExpiry time.Time `db:"soonest_expiry"`
+	}
+	if err := it.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []model.ExpiringMatch{}, nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.RecipeID
+	}
+
+	recipes, err := it.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ExpiringMatch, 0, len(rows))
+	for i, row := range rows {
+		if recipes[i] == nil {
+			continue
+		}
+		result = append(result, model.ExpiringMatch{
+			RecipeMatch: model.RecipeMatch{
+				RecipeCategoryIngredients: *recipes[i],
+				MatchedCount:              row.MatchedCount,
+				TotalCount:                row.TotalCount,
+				Coverage:                  row.Coverage,
+			},
+			ExpiringCount: row.ExpiringCount,
+			SoonestExpiry: row.SoonestExpiry,
+		})
+	}
+
+	return result, nil
+}
+
+// GetByIDs загружает рецепты в порядке ids; на месте ненайденных рецептов остаётся nil
+func (it *RecipeRepository) GetByIDs(ctx context.Context, ids []string) ([]*model.RecipeCategoryIngredients, error) {
+	result := make([]*model.RecipeCategoryIngredients, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	recipes, err := it.selectRecipes(ctx, it.selectRecipe().Where("it.id = ANY(?)", pq.Array(ids)))
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*model.RecipeCategoryIngredients, len(recipes))
+	for i := range recipes {
+		byID[recipes[i].Recipe.ID] = &recipes[i]
+	}
+
+	for i, id := range ids {
+		result[i] = byID[id]
+	}
+	return result, nil
+}
+
+// noRecipeIngredient — условие «ни один ингредиент рецепта it не удовлетворяет condition»
+func noRecipeIngredient(condition string) string {
+	return "NOT EXISTS (SELECT 1 FROM recipe_ingredients ri JOIN ingredients i ON i.id = ri.ingredient_id WHERE ri.recipe_id = it.id AND " + condition + ")"
+}
+
+func (it *RecipeRepository) selectRecipe() squirrel.SelectBuilder {
+	return it.sq.
+		Select(
+			"it.id", "it.title", "COALESCE(it.category_id, '') AS category_id", "it.prep_time_min", "it.cook_time_min", "it.method", "it.created_at", "it.image_url", "it.energy", "it.fat", "it.protein", "it.carbs", "it.fiber", "it.sugar", "it.sodium", "it.servings", "it.nutrition_override", "it.nutrition_skipped", "COALESCE(it.author_id, '') AS author_id", "COALESCE(it.image_file_id, '') AS image_file_id",
+			"it.avg_rating", "it.rating_count", "it.diet_tags",
+			"COALESCE(c.id, '') AS category_id", "COALESCE(c.name, '') AS category_name", "COALESCE(c.image_url, '') AS category_image_url",
+		).
+		From("recipes it").
+		// основной категории может не быть, если её удалили, а других у рецепта не было
+		LeftJoin("recipe_categories c ON it.category_id = c.id")
+}
 
+func (it *RecipeRepository) selectRecipes(ctx context.Context, builder squirrel.SelectBuilder) ([]model.RecipeCategoryIngredients, error) {
 	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, err
@@ -126,30 +434,68 @@ func (it *RecipeRepository) GetAll(ctx context.Context, search, categoryID strin
 
 	var rows []struct {
 		model.Recipe
-		CategoryID       string `db:"category_id"`
-		CategoryName     string `db:"category_name"`
-		CategoryImageURL string `db:"category_image_url"`
+		CategoryID       string         `db:"category_id"`
+		CategoryName     string         `db:"category_name"`
+		CategoryImageURL string         `db:"category_image_url"`
+		Rank             float64        `db:"rank"`
+		IsFavorite       bool           `db:"is_favorite"`
+		TitleHighlight   sql.NullString `db:"title_highlight"`
+		MethodHighlight  sql.NullString `db:"method_highlight"`
 	}
 	if err := it.db.SelectContext(ctx, &rows, query, args...); err != nil {
 		return nil, err
 	}
 
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	// Ингредиенты, категории и теги всех рецептов страницы загружаются одним запросом каждые
+	ingredients, err := it.getIngredientsByRecipeIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := it.getCategoriesByRecipeIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := it.getTagsByRecipeIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
 	var result []model.RecipeCategoryIngredients
 	for _, row := range rows {
-		ingredients, err := it.getIngredientsByRecipeID(ctx, row.ID)
-		if err != nil {
-			return nil, err
+		recipeIngredients := ingredients[row.ID]
+		if recipeIngredients == nil {
+			recipeIngredients = []model.IngredientWithAmount{}
 		}
 
-		result = append(result, model.RecipeCategoryIngredients{
+		recipe := model.RecipeCategoryIngredients{
 			Recipe: row.Recipe,
 			Category: model.Category{
 				ID:       row.CategoryID,
 				Name:     row.CategoryName,
 				ImageUrl: row.CategoryImageURL,
 			},
-			Ingredients: ingredients,
-		})
+			Categories:  nonNilCategories(categories[row.ID]),
+			Tags:        nonNilTags(tags[row.ID]),
+			Ingredients: recipeIngredients,
+			Diet:        model.DietLabels(row.Recipe.DietTags, recipeIngredients),
+			Rank:        row.Rank,
+			IsFavorite:  row.IsFavorite,
+		}
+		if row.TitleHighlight.Valid {
+			recipe.Highlight = &model.RecipeHighlight{
+				Title:  row.TitleHighlight.String,
+				Method: row.MethodHighlight.String,
+			}
+		}
+
+		result = append(result, recipe)
 	}
 
 	return result, nil
@@ -157,7 +503,7 @@ func (it *RecipeRepository) GetAll(ctx context.Context, search, categoryID strin
 
 func (it *RecipeRepository) getIngredientsByRecipeID(ctx context.Context, recipeID string) ([]model.IngredientWithAmount, error) {
 	query, args, err := it.sq.
-		Select("i.id", "i.name", "i.image_url", "ri.amount", "ri.unit").
+		Select("i.id", "i.name", "i.image_url", "ri.amount", "ri.unit", "i.allergens", "i.is_animal", "i.is_meat", "i.allergens_reviewed").
 		From("recipe_ingredients ri").
 		Join("ingredients i ON i.id = ri.ingredient_id").
 		Where(squirrel.Eq{"ri.recipe_id": recipeID}).
@@ -173,6 +519,168 @@ func (it *RecipeRepository) getIngredientsByRecipeID(ctx context.Context, recipe
 	return ingredients, nil
 }
 
+// getIngredientsByRecipeIDs загружает ингредиенты сразу нескольких рецептов и группирует их по recipe_id
+func (it *RecipeRepository) getIngredientsByRecipeIDs(ctx context.Context, recipeIDs []string) (map[string][]model.IngredientWithAmount, error) {
+	result := make(map[string][]model.IngredientWithAmount, len(recipeIDs))
+	if len(recipeIDs) == 0 {
+		return result, nil
+	}
+
+	query, args, err := it.sq.
+		Select("ri.recipe_id", "i.id", "i.name", "i.image_url", "ri.amount", "ri.unit", "i.allergens", "i.is_animal", "i.is_meat", "i.allergens_reviewed").
+		From("recipe_ingredients ri").
+		Join("ingredients i ON i.id = ri.ingredient_id").
+		Where("ri.recipe_id = ANY(?)", pq.Array(recipeIDs)).
+		OrderBy("ri.recipe_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		RecipeID string `db:"recipe_id"`
+		model.IngredientWithAmount
+	}
+	if err := it.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.RecipeID] = append(result[row.RecipeID], row.IngredientWithAmount)
+	}
+	return result, nil
+}
+
+// getCategoriesByRecipeIDs загружает все категории рецептов и группирует их по recipe_id
+func (it *RecipeRepository) getCategoriesByRecipeIDs(ctx context.Context, recipeIDs []string) (map[string][]model.Category, error) {
+	result := make(map[string][]model.Category, len(recipeIDs))
+	if len(recipeIDs) == 0 {
+		return result, nil
+	}
+
+	query, args, err := it.sq.
+		Select("rcl.recipe_id", "c.id", "c.name", "COALESCE(c.image_url, '') AS image_url", "COALESCE(c.image_file_id, '') AS image_file_id").
+		From("recipe_category_links rcl").
+		Join("recipe_categories c ON c.id = rcl.category_id").
+		Where("rcl.recipe_id = ANY(?)", pq.Array(recipeIDs)).
+		OrderBy("rcl.recipe_id", "rcl.position").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		RecipeID string `db:"recipe_id"`
+		model.Category
+	}
+	if err := it.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.RecipeID] = append(result[row.RecipeID], row.Category)
+	}
+	return result, nil
+}
+
+// getTagsByRecipeIDs загружает теги рецептов и группирует их по recipe_id
+func (it *RecipeRepository) getTagsByRecipeIDs(ctx context.Context, recipeIDs []string) (map[string][]model.Tag, error) {
+	result := make(map[string][]model.Tag, len(recipeIDs))
+	if len(recipeIDs) == 0 {
+		return result, nil
+	}
+
+	query, args, err := it.sq.
+		Select("rt.recipe_id", "t.id", "t.name", "t.kind").
+		From("recipe_tags rt").
+		Join("tags t ON t.id = rt.tag_id").
+		Where("rt.recipe_id = ANY(?)", pq.Array(recipeIDs)).
+		OrderBy("rt.recipe_id", "t.kind", "t.name").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		RecipeID string `db:"recipe_id"`
+		model.Tag
+	}
+	if err := it.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.RecipeID] = append(result[row.RecipeID], row.Tag)
+	}
+	return result, nil
+}
+
+// SetCategoriesWithTx заменяет категории рецепта; порядок categoryIDs сохраняется в position
+func (it *RecipeRepository) SetCategoriesWithTx(ctx context.Context, tx *sqlx.Tx, recipeID string, categoryIDs []string) error {
+	query, args, err := it.sq.Delete("recipe_category_links").
+		Where(squirrel.Eq{"recipe_id": recipeID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	insert := it.sq.Insert("recipe_category_links").
+		Columns("recipe_id", "category_id", "position")
+	for i, categoryID := range categoryIDs {
+		insert = insert.Values(recipeID, categoryID, i)
+	}
+
+	query, args, err = insert.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.NewPubErr("category not found").SetHTTPCode(http.StatusNotFound).SetCause(err)
+	}
+	return err
+}
+
+// SetTagsWithTx заменяет теги рецепта
+func (it *RecipeRepository) SetTagsWithTx(ctx context.Context, tx *sqlx.Tx, recipeID string, tagIDs []string) error {
+	query, args, err := it.sq.Delete("recipe_tags").
+		Where(squirrel.Eq{"recipe_id": recipeID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	insert := it.sq.Insert("recipe_tags").
+		Columns("recipe_id", "tag_id")
+	for _, tagID := range tagIDs {
+		insert = insert.Values(recipeID, tagID)
+	}
+
+	query, args, err = insert.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.NewPubErr("tag not found").SetHTTPCode(http.StatusNotFound).SetCause(err)
+	}
+	return err
+}
+
 func (it *RecipeRepository) Update(ctx context.Context, recipe *model.Recipe) error {
 	query, args, err := it.sq.Update("recipes").
 		Set("title", recipe.Title).
@@ -181,9 +689,18 @@ func (it *RecipeRepository) Update(ctx context.Context, recipe *model.Recipe) er
 		Set("cook_time_min", recipe.CookTimeMin).
 		Set("method", recipe.Method).
 		Set("image_url", recipe.ImageURL).
+		Set("image_file_id", nullIfEmpty(recipe.ImageFileID)).
 		Set("energy", recipe.Energy).
 		Set("fat", recipe.Fat).
 		Set("protein", recipe.Protein).
+		Set("carbs", recipe.Carbs).
+		Set("fiber", recipe.Fiber).
+		Set("sugar", recipe.Sugar).
+		Set("sodium", recipe.Sodium).
+		Set("servings", recipe.Servings).
+		Set("nutrition_override", recipe.NutritionOverride).
+		Set("nutrition_skipped", stringArray(recipe.NutritionSkipped)).
+		Set("diet_tags", stringArray(recipe.DietTags)).
 		Where(squirrel.Eq{"id": recipe.ID}).
 		ToSql()
 	if err != nil {
@@ -206,19 +723,41 @@ func (it *RecipeRepository) Delete(ctx context.Context, id string) error {
 
 func (it *RecipeRepository) BatchInsert(ctx context.Context, recipes []model.Recipe) error {
 	q := it.sq.Insert("recipes").
-		Columns("id", "title", "category_id", "prep_time_min", "cook_time_min", "method", "created_at", "image_url", "energy", "fat", "protein")
+		Columns("id", "title", "category_id", "prep_time_min", "cook_time_min", "method", "created_at", "image_url", "energy", "fat", "protein", "servings")
 
+	ids := make([]string, 0, len(recipes))
 	for _, rec := range recipes {
 		if rec.ID == "" {
 			rec.ID = uuid.New().String()
 		}
-		q = q.Values(rec.ID, rec.Title, rec.CategoryID, rec.PrepTimeMin, rec.CookTimeMin, rec.Method, time.Now(), rec.ImageURL, rec.Energy, rec.Fat, rec.Protein)
+		if rec.Servings == 0 {
+			rec.Servings = 1
+		}
+		ids = append(ids, rec.ID)
+		q = q.Values(rec.ID, rec.Title, rec.CategoryID, rec.PrepTimeMin, rec.CookTimeMin, rec.Method, time.Now(), rec.ImageURL, rec.Energy, rec.Fat, rec.Protein, rec.Servings)
 	}
 
 	query, args, err := q.ToSql()
 	if err != nil {
 		return err
 	}
+	if _, err := it.db.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	// Основная категория попадает и в recipe_category_links, иначе рецепт не найдётся по category_id
+	links := squirrel.Select("id", "category_id").
+		From("recipes").
+		Where("id = ANY(?)", pq.Array(ids)).
+		Where("category_id IS NOT NULL")
+	query, args, err = it.sq.Insert("recipe_category_links").
+		Columns("recipe_id", "category_id").
+		Select(links).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		return err
+	}
 	_, err = it.db.ExecContext(ctx, query, args...)
 	return err
 }
@@ -245,7 +784,16 @@ func (it *RecipeRepository) UpdateWithTx(ctx context.Context, tx *sqlx.Tx, recip
 		Set("energy", recipe.Energy).
 		Set("fat", recipe.Fat).
 		Set("protein", recipe.Protein).
+		Set("carbs", recipe.Carbs).
+		Set("fiber", recipe.Fiber).
+		Set("sugar", recipe.Sugar).
+		Set("sodium", recipe.Sodium).
+		Set("servings", recipe.Servings).
+		Set("nutrition_override", recipe.NutritionOverride).
+		Set("nutrition_skipped", stringArray(recipe.NutritionSkipped)).
+		Set("diet_tags", stringArray(recipe.DietTags)).
 		Set("image_url", recipe.ImageURL).
+		Set("image_file_id", nullIfEmpty(recipe.ImageFileID)).
 		Where(squirrel.Eq{"id": recipe.ID})
 
 	query, args, err := queryBuilder.ToSql()
@@ -256,3 +804,32 @@ func (it *RecipeRepository) UpdateWithTx(ctx context.Context, tx *sqlx.Tx, recip
 	_, err = tx.ExecContext(ctx, query, args...)
 	return err
 }
+
+func nullIfEmpty(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func nonNilCategories(categories []model.Category) []model.Category {
+	if categories == nil {
+		return []model.Category{}
+	}
+	return categories
+}
+
+func nonNilTags(tags []model.Tag) []model.Tag {
+	if tags == nil {
+		return []model.Tag{}
+	}
+	return tags
+}
+
+// stringArray не даёт nil-срезу превратиться в NULL в колонках TEXT[] NOT NULL
+func stringArray(values []string) pq.StringArray {
+	if values == nil {
+		return pq.StringArray{}
+	}
+	return values
+}
@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/migrations"
+	"CookFinder.Backend/pkg/db"
+	"CookFinder.Backend/pkg/uuid"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+const (
+	benchRecipes              = 500
+	benchIngredients          = 60
+	benchIngredientsPerRecipe = 8
+)
+
+// BenchmarkRecipeIngredients сравнивает загрузку ингредиентов по одному запросу на рецепт и одним пакетным запросом.
+// Нужен локальный Postgres (make dev-up), адрес задаётся через BENCH_DATABASE_URL.
+func BenchmarkRecipeIngredients(b *testing.B) {
+	repo, recipeIDs := seedBenchRecipes(b)
+	ctx := context.Background()
+
+	for _, n := range []int{20, 100, benchRecipes} {
+		ids := recipeIDs[:n]
+
+		b.Run(fmt.Sprintf("per_recipe/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, id := range ids {
+					if _, err := repo.getIngredientsByRecipeID(ctx, id); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("batched/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.getIngredientsByRecipeIDs(ctx, ids); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func seedBenchRecipes(b *testing.B) (*RecipeRepository, []string) {
+	b.Helper()
+
+	dsn := os.Getenv("BENCH_DATABASE_URL")
+	if dsn == "" {
+		b.Skip("BENCH_DATABASE_URL is not set")
+	}
+
+	conn, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		b.Fatalf("failed to connect to DB: %v", err)
+	}
+	b.Cleanup(func() { conn.Close() })
+
+	if err := db.Migrate(conn.DB, migrations.FS, "cook_finder", ""); err != nil {
+		b.Fatalf("failed to migrate: %v", err)
+	}
+
+	ctx := context.Background()
+	run := uuid.V7().String()
+
+	category := &model.Category{ID: uuid.V7().String(), Name: "bench-" + run}
+	if err := NewCategoryRepository(conn).Create(ctx, category); err != nil {
+		b.Fatal(err)
+	}
+
+	ingredientIDs := make([]string, benchIngredients)
+	ingredientRepo := NewIngredientRepository(conn)
+	for i := range ingredientIDs {
+		ingredientIDs[i] = uuid.V7().String()
+		ingredient := &model.Ingredient{ID: ingredientIDs[i], Name: fmt.Sprintf("bench-%s-%d", run, i)}
+		if err := ingredientRepo.Create(ctx, ingredient); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Cleanup(func() {
+		// рецепты и recipe_ingredients удаляются каскадом
+		_ = NewCategoryRepository(conn).Delete(ctx, category.ID)
+		for _, id := range ingredientIDs {
+			_ = ingredientRepo.Delete(ctx, id)
+		}
+	})
+
+	repo := NewRecipeRepository(conn)
+
+	recipes := make([]model.Recipe, benchRecipes)
+	recipeIDs := make([]string, benchRecipes)
+	for i := range recipes {
+		recipeIDs[i] = uuid.V7().String()
+		recipes[i] = model.Recipe{ID: recipeIDs[i], Title: fmt.Sprintf("bench recipe %d", i), CategoryID: category.ID}
+	}
+	if err := repo.BatchInsert(ctx, recipes); err != nil {
+		b.Fatal(err)
+	}
+
+	insert := repo.sq.Insert("recipe_ingredients").Columns("recipe_id", "ingredient_id", "amount", "unit")
+	for i, recipeID := range recipeIDs {
+		for j := 0; j < benchIngredientsPerRecipe; j++ {
+			insert = insert.Values(recipeID, ingredientIDs[(i+j)%benchIngredients], 100, "g")
+		}
+	}
+	query, args, err := insert.ToSql()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err := conn.ExecContext(ctx, query, args...); err != nil {
+		b.Fatal(err)
+	}
+
+	return repo, recipeIDs
+}
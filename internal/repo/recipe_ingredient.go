@@ -60,6 +60,21 @@ func (it *RecipeIngredientRepository) GetByRecipeID(ctx context.Context, recipeI
 	return result, err
 }
 
+// GetByRecipeIDs загружает ингредиенты нескольких рецептов одним запросом
+func (it *RecipeIngredientRepository) GetByRecipeIDs(ctx context.Context, recipeIDs []string) ([]model.RecipeIngredient, error) {
+	query, args, err := it.sq.Select("*").
+		From("recipe_ingredients").
+		Where(squirrel.Eq{"recipe_id": recipeIDs}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.RecipeIngredient, 0)
+	err = it.db.SelectContext(ctx, &result, query, args...)
+	return result, err
+}
+
 func (it *RecipeIngredientRepository) DeleteByRecipeID(ctx context.Context, recipeID string) error {
 	query, args, err := it.sq.Delete("recipe_ingredients").
 		Where(squirrel.Eq{"recipe_id": recipeID}).
@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type RecipeStepRepository struct {
+	db *sqlx.DB
+	sq squirrel.StatementBuilderType
+}
+
+func NewRecipeStepRepository(db *sqlx.DB) *RecipeStepRepository {
+	return &RecipeStepRepository{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (it *RecipeStepRepository) GetByRecipeID(ctx context.Context, recipeID string) ([]model.RecipeStep, error) {
+	query, args, err := it.sq.
+		Select("s.recipe_id", "s.ordinal", "s.text", "COALESCE(s.duration_sec, 0) AS duration_sec",
+			"COALESCE(s.image_file_id, '') AS image_file_id", "COALESCE(f.path, '') AS image_url").
+		From("recipe_steps s").
+		LeftJoin("files f ON f.id = s.image_file_id").
+		Where(squirrel.Eq{"s.recipe_id": recipeID}).
+		OrderBy("s.ordinal").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]model.RecipeStep, 0)
+	err = it.db.SelectContext(ctx, &steps, query, args...)
+	return steps, err
+}
+
+// ReplaceWithTx заменяет все шаги рецепта одним набором в рамках транзакции
+func (it *RecipeStepRepository) ReplaceWithTx(ctx context.Context, tx *sqlx.Tx, recipeID string, steps []model.RecipeStep) error {
+	query, args, err := it.sq.Delete("recipe_steps").
+		Where(squirrel.Eq{"recipe_id": recipeID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if len(steps) == 0 {
+		return nil
+	}
+
+	insert := it.sq.Insert("recipe_steps").
+		Columns("recipe_id", "ordinal", "text", "duration_sec", "image_file_id")
+	for _, step := range steps {
+		insert = insert.Values(recipeID, step.Ordinal, step.Text, nullIfZero(step.DurationSec), nullIfEmpty(step.ImageFileID))
+	}
+
+	query, args, err = insert.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.NewPubErr("step image file not found").SetCause(err)
+	}
+	return err
+}
+
+func nullIfZero(value int) any {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
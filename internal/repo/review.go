@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type ReviewRepository struct {
+	db *sqlx.DB
+	sq squirrel.StatementBuilderType
+}
+
+func NewReviewRepository(db *sqlx.DB) *ReviewRepository {
+	return &ReviewRepository{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Upsert создаёт или заменяет отзыв пользователя; агрегаты рецепта пересчитывает триггер
+func (it *ReviewRepository) Upsert(ctx context.Context, review *model.Review) error {
+	query, args, err := it.sq.Insert("recipe_reviews").
+		Columns("recipe_id", "user_id", "rating", "body", "created_at", "updated_at").
+		Values(review.RecipeID, review.UserID, review.Rating, review.Body, review.CreatedAt, review.UpdatedAt).
+		Suffix("ON CONFLICT (recipe_id, user_id) DO UPDATE SET rating = EXCLUDED.rating, body = EXCLUDED.body, updated_at = EXCLUDED.updated_at").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.ErrResourceNotFound.SetCause(err)
+	}
+	return err
+}
+
+func (it *ReviewRepository) Get(ctx context.Context, recipeID, userID string) (*model.Review, error) {
+	query, args, err := it.sq.Select("*").
+		From("recipe_reviews").
+		Where(squirrel.Eq{"recipe_id": recipeID, "user_id": userID}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var review model.Review
+	if err := it.db.GetContext(ctx, &review, query, args...); err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (it *ReviewRepository) Delete(ctx context.Context, recipeID, userID string) error {
+	query, args, err := it.sq.Delete("recipe_reviews").
+		Where(squirrel.Eq{"recipe_id": recipeID, "user_id": userID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+var reviewSortFields = sortFields[model.Review]{
+	"created_at": {column: "created_at", value: func(r model.Review) any { return r.CreatedAt }},
+	"rating":     {column: "rating", value: func(r model.Review) any { return r.Rating }},
+}
+
+func (it *ReviewRepository) GetByRecipeID(ctx context.Context, recipeID string, page model.PageRequest) (model.Page[model.Review], error) {
+	builder := it.sq.Select("*").
+		From("recipe_reviews").
+		Where(squirrel.Eq{"recipe_id": recipeID})
+
+	// в пределах рецепта отзыв однозначно определяется пользователем
+	builder, field, err := paginate(builder, page, reviewSortFields, "user_id")
+	if err != nil {
+		return model.Page[model.Review]{}, err
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return model.Page[model.Review]{}, err
+	}
+
+	var reviews []model.Review
+	if err := it.db.SelectContext(ctx, &reviews, query, args...); err != nil {
+		return model.Page[model.Review]{}, err
+	}
+
+	return nextPage(reviews, page, field, func(r model.Review) string { return r.UserID })
+}
@@ -0,0 +1,191 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type ShoppingListRepository struct {
+	db *sqlx.DB
+	sq squirrel.StatementBuilderType
+}
+
+func NewShoppingListRepository(db *sqlx.DB) *ShoppingListRepository {
+	return &ShoppingListRepository{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Create сохраняет список вместе с рецептами и позициями в одной транзакции
+func (it *ShoppingListRepository) Create(ctx context.Context, list *model.ShoppingList) error {
+	tx, err := it.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := it.sq.Insert("shopping_lists").
+		Columns("id", "user_id", "name", "created_at").
+		Values(list.ID, list.UserID, list.Name, list.CreatedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if len(list.Recipes) > 0 {
+		builder := it.sq.Insert("shopping_list_recipes").Columns("list_id", "recipe_id", "servings")
+		for _, r := range list.Recipes {
+			builder = builder.Values(list.ID, r.RecipeID, r.Servings)
+		}
+		if err := insertWithTx(ctx, tx, builder); err != nil {
+			return err
+		}
+	}
+
+	if len(list.Items) > 0 {
+		builder := it.sq.Insert("shopping_list_items").Columns("id", "list_id", "ingredient_id", "amount", "unit", "checked")
+		for _, item := range list.Items {
+			builder = builder.Values(item.ID, list.ID, item.IngredientID, item.Amount, item.Unit, item.Checked)
+		}
+		if err := insertWithTx(ctx, tx, builder); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertWithTx(ctx context.Context, tx *sqlx.Tx, builder squirrel.InsertBuilder) error {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return puberr.ErrResourceNotFound.SetCause(err)
+	}
+	return err
+}
+
+func (it *ShoppingListRepository) selectList() squirrel.SelectBuilder {
+	return it.sq.
+		Select("id", "user_id", "name", "COALESCE(share_token, '') AS share_token", "created_at").
+		From("shopping_lists")
+}
+
+func (it *ShoppingListRepository) GetByID(ctx context.Context, id string) (*model.ShoppingList, error) {
+	return it.get(ctx, squirrel.Eq{"id": id})
+}
+
+func (it *ShoppingListRepository) GetByShareToken(ctx context.Context, token string) (*model.ShoppingList, error) {
+	return it.get(ctx, squirrel.Eq{"share_token": token})
+}
+
+func (it *ShoppingListRepository) get(ctx context.Context, where squirrel.Eq) (*model.ShoppingList, error) {
+	query, args, err := it.selectList().Where(where).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var list model.ShoppingList
+	if err := it.db.GetContext(ctx, &list, query, args...); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetByUserID возвращает списки пользователя без рецептов и позиций, новые первыми
+func (it *ShoppingListRepository) GetByUserID(ctx context.Context, userID string) ([]model.ShoppingList, error) {
+	query, args, err := it.selectList().
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("created_at DESC", "id DESC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make([]model.ShoppingList, 0)
+	err = it.db.SelectContext(ctx, &lists, query, args...)
+	return lists, err
+}
+
+func (it *ShoppingListRepository) GetRecipes(ctx context.Context, listID string) ([]model.ShoppingListRecipe, error) {
+	query, args, err := it.sq.Select("slr.recipe_id", "r.title", "slr.servings").
+		From("shopping_list_recipes slr").
+		Join("recipes r ON r.id = slr.recipe_id").
+		Where(squirrel.Eq{"slr.list_id": listID}).
+		OrderBy("r.title").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	recipes := make([]model.ShoppingListRecipe, 0)
+	err = it.db.SelectContext(ctx, &recipes, query, args...)
+	return recipes, err
+}
+
+// GetItems возвращает позиции списка с названием и отделом магазина ингредиента
+func (it *ShoppingListRepository) GetItems(ctx context.Context, listID string) ([]model.ShoppingListItem, error) {
+	query, args, err := it.sq.Select("sli.id", "sli.ingredient_id", "i.name", "i.aisle", "sli.amount", "sli.unit", "sli.checked").
+		From("shopping_list_items sli").
+		Join("ingredients i ON i.id = sli.ingredient_id").
+		Where(squirrel.Eq{"sli.list_id": listID}).
+		OrderBy("i.name", "sli.unit").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]model.ShoppingListItem, 0)
+	err = it.db.SelectContext(ctx, &items, query, args...)
+	return items, err
+}
+
+// SetChecked отмечает позицию купленной; false, если в списке нет такой позиции
+func (it *ShoppingListRepository) SetChecked(ctx context.Context, listID, itemID string, checked bool) (bool, error) {
+	query, args, err := it.sq.Update("shopping_list_items").
+		Set("checked", checked).
+		Where(squirrel.Eq{"id": itemID, "list_id": listID}).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	res, err := it.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// SetShareToken выдаёт или, при пустом token, отзывает ссылку только для чтения
+func (it *ShoppingListRepository) SetShareToken(ctx context.Context, id, token string) error {
+	query, args, err := it.sq.Update("shopping_lists").
+		Set("share_token", nullIfEmpty(token)).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (it *ShoppingListRepository) Delete(ctx context.Context, id string) error {
+	query, args, err := it.sq.Delete("shopping_lists").Where(squirrel.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
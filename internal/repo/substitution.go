@@ -0,0 +1,258 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+	"net/http"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type SubstitutionRepository struct {
+	db *sqlx.DB
+	sq squirrel.StatementBuilderType
+}
+
+func NewSubstitutionRepository(db *sqlx.DB) *SubstitutionRepository {
+	return &SubstitutionRepository{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+var errSubstitutionIngredientNotFound = puberr.NewPubErr("ingredient not found").SetHTTPCode(http.StatusNotFound)
+
+// Create сохраняет замену вместе с заменителями в одной транзакции
+func (it *SubstitutionRepository) Create(ctx context.Context, sub *model.IngredientSubstitution) error {
+	tx, err := it.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := it.sq.Insert("ingredient_substitutions").
+		Columns("id", "ingredient_id", "context", "bidirectional", "notes", "created_at").
+		Values(sub.ID, sub.IngredientID, sub.Context, sub.Bidirectional, sub.Notes, sub.CreatedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		if isForeignKeyViolation(err) {
+			return errSubstitutionIngredientNotFound.SetCause(err)
+		}
+		return err
+	}
+
+	if err := it.insertPartsWithTx(ctx, tx, sub); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Update заменяет поля и состав замены
+func (it *SubstitutionRepository) Update(ctx context.Context, sub *model.IngredientSubstitution) error {
+	tx, err := it.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args, err := it.sq.Update("ingredient_substitutions").
+		Set("ingredient_id", sub.IngredientID).
+		Set("context", sub.Context).
+		Set("bidirectional", sub.Bidirectional).
+		Set("notes", sub.Notes).
+		Where(squirrel.Eq{"id": sub.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		if isForeignKeyViolation(err) {
+			return errSubstitutionIngredientNotFound.SetCause(err)
+		}
+		return err
+	}
+
+	query, args, err = it.sq.Delete("ingredient_substitution_parts").
+		Where(squirrel.Eq{"substitution_id": sub.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if err := it.insertPartsWithTx(ctx, tx, sub); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (it *SubstitutionRepository) insertPartsWithTx(ctx context.Context, tx *sqlx.Tx, sub *model.IngredientSubstitution) error {
+	builder := it.sq.Insert("ingredient_substitution_parts").
+		Columns("substitution_id", "ingredient_id", "ratio", "position")
+	for i, part := range sub.Parts {
+		builder = builder.Values(sub.ID, part.IngredientID, part.Ratio, i)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, query, args...)
+	if isForeignKeyViolation(err) {
+		return errSubstitutionIngredientNotFound.SetCause(err)
+	}
+	return err
+}
+
+func (it *SubstitutionRepository) Delete(ctx context.Context, id string) error {
+	query, args, err := it.sq.Delete("ingredient_substitutions").Where(squirrel.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (it *SubstitutionRepository) selectSubstitution() squirrel.SelectBuilder {
+	return it.sq.
+		Select("s.id", "s.ingredient_id", "i.name AS ingredient_name", "s.context", "s.bidirectional", "s.notes", "s.created_at").
+		From("ingredient_substitutions s").
+		Join("ingredients i ON i.id = s.ingredient_id")
+}
+
+func (it *SubstitutionRepository) GetByID(ctx context.Context, id string) (*model.IngredientSubstitution, error) {
+	subs, err := it.selectSubstitutions(ctx, it.selectSubstitution().Where(squirrel.Eq{"s.id": id}))
+	if err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return nil, puberr.ErrNotFound
+	}
+	return &subs[0], nil
+}
+
+var substitutionSortFields = sortFields[model.IngredientSubstitution]{
+	"created_at": {column: "s.id", value: func(s model.IngredientSubstitution) any { return s.ID }},
+}
+
+// GetAll возвращает замены постранично; ingredientID ограничивает выборку заменами одного ингредиента
+func (it *SubstitutionRepository) GetAll(ctx context.Context, ingredientID string, page model.PageRequest) (model.Page[model.IngredientSubstitution], error) {
+	builder := it.selectSubstitution()
+	if ingredientID != "" {
+		builder = builder.Where(squirrel.Eq{"s.ingredient_id": ingredientID})
+	}
+
+	builder, field, err := paginate(builder, page, substitutionSortFields, "s.id")
+	if err != nil {
+		return model.Page[model.IngredientSubstitution]{}, err
+	}
+
+	subs, err := it.selectSubstitutions(ctx, builder)
+	if err != nil {
+		return model.Page[model.IngredientSubstitution]{}, err
+	}
+	return nextPage(subs, page, field, func(s model.IngredientSubstitution) string { return s.ID })
+}
+
+// GetForIngredients возвращает замены для любого из ingredientIDs, включая двусторонние,
+// где ингредиент указан заменителем. Обратные замены не разворачиваются — это делает сервис.
+func (it *SubstitutionRepository) GetForIngredients(ctx context.Context, ingredientIDs []string) ([]model.IngredientSubstitution, error) {
+	if len(ingredientIDs) == 0 {
+		return []model.IngredientSubstitution{}, nil
+	}
+
+	ids := pq.Array(ingredientIDs)
+	builder := it.selectSubstitution().
+		Where(squirrel.Or{
+			squirrel.Expr("s.ingredient_id = ANY(?)", ids),
+			squirrel.Expr("s.bidirectional AND EXISTS (SELECT 1 FROM ingredient_substitution_parts p WHERE p.substitution_id = s.id AND p.ingredient_id = ANY(?))", ids),
+		}).
+		OrderBy("s.id")
+	return it.selectSubstitutions(ctx, builder)
+}
+
+// GetCoveredBy возвращает ингредиенты, которые можно заменить тем, что есть в pantry:
+// все заменители есть в наличии, либо это обратная сторона двусторонней замены.
+// Учитываются только замены с контекстом any: для каких блюд годится рецепт, неизвестно.
+func (it *SubstitutionRepository) GetCoveredBy(ctx context.Context, pantry []string) ([]string, error) {
+	if len(pantry) == 0 {
+		return []string{}, nil
+	}
+
+	ids := pq.Array(pantry)
+	query, args, err := it.sq.
+		Select("s.ingredient_id").
+		From("ingredient_substitutions s").
+		Where(squirrel.Eq{"s.context": model.SubstitutionAny}).
+		Where("NOT EXISTS (SELECT 1 FROM ingredient_substitution_parts p WHERE p.substitution_id = s.id AND NOT p.ingredient_id = ANY(?))", ids).
+		Suffix("UNION SELECT p.ingredient_id FROM ingredient_substitutions s JOIN ingredient_substitution_parts p ON p.substitution_id = s.id WHERE s.context = ? AND s.bidirectional AND s.ingredient_id = ANY(?)",
+			model.SubstitutionAny, ids).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	covered := make([]string, 0)
+	err = it.db.SelectContext(ctx, &covered, query, args...)
+	return covered, err
+}
+
+func (it *SubstitutionRepository) selectSubstitutions(ctx context.Context, builder squirrel.SelectBuilder) ([]model.IngredientSubstitution, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	subs := make([]model.IngredientSubstitution, 0)
+	if err := it.db.SelectContext(ctx, &subs, query, args...); err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return subs, nil
+	}
+
+	ids := make([]string, len(subs))
+	for i, sub := range subs {
+		ids[i] = sub.ID
+	}
+	parts, err := it.getParts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		subs[i].Parts = parts[subs[i].ID]
+	}
+	return subs, nil
+}
+
+func (it *SubstitutionRepository) getParts(ctx context.Context, substitutionIDs []string) (map[string][]model.SubstitutionPart, error) {
+	query, args, err := it.sq.
+		Select("p.substitution_id", "p.ingredient_id", "i.name", "p.ratio").
+		From("ingredient_substitution_parts p").
+		Join("ingredients i ON i.id = p.ingredient_id").
+		Where("p.substitution_id = ANY(?)", pq.Array(substitutionIDs)).
+		OrderBy("p.substitution_id", "p.position").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []model.SubstitutionPart
+	if err := it.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]model.SubstitutionPart, len(substitutionIDs))
+	for _, row := range rows {
+		result[row.SubstitutionID] = append(result[row.SubstitutionID], row)
+	}
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+	"net/http"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type TagRepository struct {
+	db *sqlx.DB
+	sq squirrel.StatementBuilderType
+}
+
+func NewTagRepository(db *sqlx.DB) *TagRepository {
+	return &TagRepository{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (it *TagRepository) Create(ctx context.Context, tag *model.Tag) error {
+	query, args, err := it.sq.Insert("tags").
+		Columns("id", "name", "kind").
+		Values(tag.ID, tag.Name, tag.Kind).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	if isUniqueViolation(err) {
+		return puberr.ErrExists.SetHTTPCode(http.StatusConflict).SetCause(err)
+	}
+	return err
+}
+
+func (it *TagRepository) GetByID(ctx context.Context, id string) (*model.Tag, error) {
+	query, args, err := it.sq.Select("*").
+		From("tags").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var tag model.Tag
+	if err := it.db.GetContext(ctx, &tag, query, args...); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// id — UUIDv7, поэтому сортировка по нему совпадает с порядком создания
+var tagSortFields = sortFields[model.Tag]{
+	"created_at": {column: "id", value: func(t model.Tag) any { return t.ID }},
+	"name":       {column: "name", value: func(t model.Tag) any { return t.Name }},
+}
+
+// GetAll возвращает теги, kind фильтрует по виду, если задан
+func (it *TagRepository) GetAll(ctx context.Context, kind string, page model.PageRequest) (model.Page[model.Tag], error) {
+	builder := it.sq.Select("*").From("tags")
+	if kind != "" {
+		builder = builder.Where(squirrel.Eq{"kind": kind})
+	}
+
+	builder, field, err := paginate(builder, page, tagSortFields, "id")
+	if err != nil {
+		return model.Page[model.Tag]{}, err
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return model.Page[model.Tag]{}, err
+	}
+
+	var tags []model.Tag
+	if err := it.db.SelectContext(ctx, &tags, query, args...); err != nil {
+		return model.Page[model.Tag]{}, err
+	}
+
+	return nextPage(tags, page, field, func(t model.Tag) string { return t.ID })
+}
+
+func (it *TagRepository) Update(ctx context.Context, tag *model.Tag) error {
+	query, args, err := it.sq.Update("tags").
+		Set("name", tag.Name).
+		Set("kind", tag.Kind).
+		Where(squirrel.Eq{"id": tag.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = it.db.ExecContext(ctx, query, args...)
+	if isUniqueViolation(err) {
+		return puberr.ErrExists.SetHTTPCode(http.StatusConflict).SetCause(err)
+	}
+	return err
+}
+
+func (it *TagRepository) Delete(ctx context.Context, id string) error {
+	query, args, err := it.sq.Delete("tags").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,52 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type UnitRepository struct {
+	db *sqlx.DB
+	sq squirrel.StatementBuilderType
+}
+
+func NewUnitRepository(db *sqlx.DB) *UnitRepository {
+	return &UnitRepository{
+		db: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// GetAll возвращает справочник единиц вместе с синонимами
+func (it *UnitRepository) GetAll(ctx context.Context) ([]model.Unit, error) {
+	query, args, err := it.sq.
+		Select("u.code", "u.name", "u.dimension", "u.system", "u.to_base").
+		Column("COALESCE(array_agg(a.alias ORDER BY a.alias) FILTER (WHERE a.alias IS NOT NULL), '{}') AS aliases").
+		From("units u").
+		LeftJoin("unit_aliases a ON a.unit_code = u.code").
+		GroupBy("u.code").
+		OrderBy("u.dimension", "u.to_base").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		model.Unit
+		Aliases pq.StringArray `db:"aliases"`
+	}
+	if err := it.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	units := make([]model.Unit, len(rows))
+	for i, row := range rows {
+		units[i] = row.Unit
+		units[i].Aliases = row.Aliases
+	}
+	return units, nil
+}
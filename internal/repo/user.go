@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"CookFinder.Backend/internal/model"
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type UserRepository struct {
+	db *sqlx.DB
+	sb squirrel.StatementBuilderType
+}
+
+func NewUserRepository(db *sqlx.DB) *UserRepository {
+	return &UserRepository{
+		db: db,
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (it *UserRepository) Create(ctx context.Context, user *model.User) error {
+	query, args, err := it.sb.Insert("users").
+		Columns("id", "email", "password_hash", "role", "created_at").
+		Values(user.ID, user.Email, user.PasswordHash, user.Role, user.CreatedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (it *UserRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
+	query, args, err := it.sb.Select("*").From("users").Where(squirrel.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var user model.User
+	if err := it.db.GetContext(ctx, &user, query, args...); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (it *UserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	query, args, err := it.sb.Select("*").From("users").Where(squirrel.Eq{"email": email}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var user model.User
+	if err := it.db.GetContext(ctx, &user, query, args...); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (it *UserRepository) UpdateRole(ctx context.Context, id, role string) error {
+	query, args, err := it.sb.Update("users").
+		Set("role", role).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = it.db.ExecContext(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/auth"
+	"CookFinder.Backend/internal/model"
+	repository "CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/uuid"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72 // ограничение bcrypt
+)
+
+type AuthService struct {
+	repo   *repository.UserRepository
+	tokens *auth.TokenManager
+}
+
+func NewAuthService(repo *repository.UserRepository, tokens *auth.TokenManager) *AuthService {
+	return &AuthService{repo: repo, tokens: tokens}
+}
+
+// Register создаёт пользователя с ролью user; повысить роль может только админ
+func (s *AuthService) Register(ctx context.Context, email, password string) (*model.User, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByEmail(ctx, email); err == nil {
+		return nil, puberr.ErrExists.SetHTTPCode(http.StatusConflict)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	user := &model.User{
+		ID:           uuid.V7().String(),
+		Email:        email,
+		PasswordHash: hash,
+		Role:         model.RoleUser,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *AuthService) Login(ctx context.Context, email, password string) (*auth.TokenPair, error) {
+	user, err := s.repo.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, puberr.ErrNotAuthorized
+		}
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, puberr.ErrNotAuthorized
+	}
+
+	return s.tokens.Issue(user)
+}
+
+// Refresh выпускает новую пару по refresh-токену. Пользователь перечитывается из БД,
+// поэтому смена роли применяется при следующем обновлении токена.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*auth.TokenPair, error) {
+	principal, err := s.tokens.Parse(refreshToken, auth.TokenRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, principal.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, puberr.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	return s.tokens.Issue(user)
+}
+
+func (s *AuthService) GetUser(ctx context.Context, id string) (*model.User, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *AuthService) SetRole(ctx context.Context, id, role string) (*model.User, error) {
+	if !model.IsValidRole(role) {
+		return nil, puberr.NewPubErr("unknown role")
+	}
+
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateRole(ctx, id, role); err != nil {
+		return nil, err
+	}
+
+	user.Role = role
+	return user, nil
+}
+
+// EnsureAdmin заводит администратора при старте, если его ещё нет, и выставляет ему роль admin
+func (s *AuthService) EnsureAdmin(ctx context.Context, email, password string) error {
+	user, err := s.repo.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, sql.ErrNoRows) {
+		user, err = s.Register(ctx, email, password)
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.Role == model.RoleAdmin {
+		return nil
+	}
+	return s.repo.UpdateRole(ctx, user.ID, model.RoleAdmin)
+}
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if _, err := mail.ParseAddress(email); err != nil {
+		return "", puberr.NewPubErr("invalid email")
+	}
+	return email, nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return "", puberr.NewPubErr("password must be 8 to 72 characters long")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
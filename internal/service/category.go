@@ -8,11 +8,12 @@ import (
 )
 
 type CategoryService struct {
-	repo *repository.CategoryRepository
+	repo     *repository.CategoryRepository
+	fileRepo *repository.FileRepository
 }
 
-func NewCategoryService(repo *repository.CategoryRepository) *CategoryService {
-	return &CategoryService{repo: repo}
+func NewCategoryService(repo *repository.CategoryRepository, fileRepo *repository.FileRepository) *CategoryService {
+	return &CategoryService{repo: repo, fileRepo: fileRepo}
 }
 
 func (s *CategoryService) Create(ctx context.Context, category *model.Category) error {
@@ -20,21 +21,31 @@ func (s *CategoryService) Create(ctx context.Context, category *model.Category)
 		category.ID = uuid.V7().String()
 	}
 
+	var err error
+	if category.ImageFileID, category.ImageUrl, err = resolveImage(ctx, s.fileRepo, category.ImageFileID, category.ImageUrl); err != nil {
+		return err
+	}
+
 	return s.repo.Create(ctx, category)
 }
 
-func (s *CategoryService) GetAll(ctx context.Context) ([]model.Category, error) {
-	return s.repo.GetAll(ctx)
+func (s *CategoryService) GetAll(ctx context.Context, page model.PageRequest) (model.Page[model.Category], error) {
+	return s.repo.GetAll(ctx, page)
 }
 
 func (s *CategoryService) GetByID(ctx context.Context, id string) (*model.Category, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
+// Delete не удаляет рецепты категории: основной у них становится следующая категория рецепта
 func (s *CategoryService) Delete(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
 
 func (s *CategoryService) Update(ctx context.Context, category *model.Category) error {
+	var err error
+	if category.ImageFileID, category.ImageUrl, err = resolveImage(ctx, s.fileRepo, category.ImageFileID, category.ImageUrl); err != nil {
+		return err
+	}
 	return s.repo.Update(ctx, category)
 }
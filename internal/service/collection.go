@@ -0,0 +1,164 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/uuid"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+type CollectionService struct {
+	repo       *repo.CollectionRepository
+	recipeRepo *repo.RecipeRepository
+}
+
+func NewCollectionService(repo *repo.CollectionRepository, recipeRepo *repo.RecipeRepository) *CollectionService {
+	return &CollectionService{
+		repo:       repo,
+		recipeRepo: recipeRepo,
+	}
+}
+
+func (s *CollectionService) Create(ctx context.Context, userID, name string) (*model.Collection, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, puberr.NewPubErr("name is required")
+	}
+
+	collection := &model.Collection{
+		ID:        uuid.V7().String(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.Create(ctx, collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+func (s *CollectionService) GetAll(ctx context.Context, userID string) ([]model.Collection, error) {
+	return s.repo.GetByUserID(ctx, userID)
+}
+
+func (s *CollectionService) Rename(ctx context.Context, userID, id, name string) (*model.Collection, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, puberr.NewPubErr("name is required")
+	}
+
+	collection, err := s.getOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Rename(ctx, id, name); err != nil {
+		return nil, err
+	}
+	collection.Name = name
+	return collection, nil
+}
+
+func (s *CollectionService) Delete(ctx context.Context, userID, id string) error {
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// GetRecipes возвращает рецепты коллекции в пользовательском порядке
+func (s *CollectionService) GetRecipes(ctx context.Context, userID, id string) ([]model.RecipeCategoryIngredients, error) {
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return nil, err
+	}
+
+	ids, err := s.repo.RecipeIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	recipes, err := s.recipeRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.RecipeCategoryIngredients, 0, len(recipes))
+	for _, recipe := range recipes {
+		if recipe != nil {
+			recipe.IsFavorite = true
+			result = append(result, *recipe)
+		}
+	}
+	return result, nil
+}
+
+func (s *CollectionService) AddRecipe(ctx context.Context, userID, id, recipeID string) error {
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.AddRecipe(ctx, id, recipeID)
+}
+
+func (s *CollectionService) RemoveRecipe(ctx context.Context, userID, id, recipeID string) error {
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.RemoveRecipe(ctx, id, recipeID)
+}
+
+// Reorder принимает полный список рецептов коллекции в новом порядке
+func (s *CollectionService) Reorder(ctx context.Context, userID, id string, recipeIDs []string) error {
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+
+	current, err := s.repo.RecipeIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !samePermutation(current, recipeIDs) {
+		return puberr.NewPubErr("recipe_ids must list every recipe of the collection exactly once")
+	}
+
+	return s.repo.Reorder(ctx, id, recipeIDs)
+}
+
+// getOwned скрывает чужие коллекции так же, как несуществующие
+func (s *CollectionService) getOwned(ctx context.Context, userID, id string) (*model.Collection, error) {
+	collection, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, puberr.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if collection.UserID != userID {
+		return nil, puberr.ErrNotFound
+	}
+	return collection, nil
+}
+
+func samePermutation(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	seen := make(map[string]int, len(a))
+	for _, id := range a {
+		seen[id]++
+	}
+	for _, id := range b {
+		if seen[id] == 0 {
+			return false
+		}
+		seen[id]--
+	}
+	return true
+}
@@ -1,31 +1,244 @@
 package service
 
 import (
+	"CookFinder.Backend/internal/imaging"
 	"CookFinder.Backend/internal/model"
 	repository "CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/internal/storage"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/uuid"
+	"bytes"
 	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
 )
 
+const (
+	// uploadPrefix — каталог исходников, загруженных по presigned-ссылке
+	uploadPrefix  = "uploads/"
+	presignExpiry = 15 * time.Minute
+)
+
+// uploadKeyPattern — ключ, выданный Presign: uploads/<UUID файла>/<имя>
+var uploadKeyPattern = regexp.MustCompile(`^uploads/([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})/([^/]+)$`)
+
 type FileService struct {
-	repo *repository.FileRepository
+	repo    *repository.FileRepository
+	storage storage.ObjectStore
 }
 
-func NewFileService(repo *repository.FileRepository) *FileService {
-	return &FileService{repo: repo}
+func NewFileService(repo *repository.FileRepository, storage storage.ObjectStore) *FileService {
+	return &FileService{repo: repo, storage: storage}
 }
 
 func (it *FileService) CreateFile(ctx context.Context, file *model.File) error {
 	return it.repo.Create(ctx, file)
 }
 
-func (it *FileService) GetAllFiles(ctx context.Context) ([]model.File, error) {
-	return it.repo.GetAll(ctx)
+// Upload перекодирует изображение в варианты без EXIF, загружает их в хранилище и сохраняет запись о файле.
+// Исходник не сохраняется: в нём остаются метаданные, включая геометки.
+func (it *FileService) Upload(ctx context.Context, name string, data []byte) (*model.File, error) {
+	return it.save(ctx, uuid.V7().String(), name, data)
+}
+
+// Presign выдаёт ссылку для загрузки исходника напрямую в бакет, минуя API.
+// Формат без декодера отклоняется сразу, иначе Complete отказал бы уже после загрузки.
+func (it *FileService) Presign(ctx context.Context, name, contentType string, size int64) (*model.PresignedUpload, error) {
+	if !imaging.IsAccepted(contentType) {
+		return nil, imaging.ErrUnsupportedFormat
+	}
+	if size <= 0 || size > imaging.MaxFileSize {
+		return nil, imaging.ErrTooLarge
+	}
+
+	key := uploadPrefix + uuid.V7().String() + "/" + sanitizeFileName(name)
+	url, err := it.storage.PresignedURL(ctx, http.MethodPut, key, presignExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PresignedUpload{
+		Key:         key,
+		URL:         url,
+		ContentType: contentType,
+		ExpiresAt:   time.Now().Add(presignExpiry),
+	}, nil
+}
+
+// Complete проверяет загруженный по Presign объект и обрабатывает его так же, как Upload.
+// Исходник из бакета удаляется и при успехе, и при отказе.
+func (it *FileService) Complete(ctx context.Context, key string) (*model.File, error) {
+	match := uploadKeyPattern.FindStringSubmatch(key)
+	if match == nil {
+		return nil, puberr.NewPubErr("invalid upload key")
+	}
+	id, name := match[1], match[2]
+
+	info, err := it.storage.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := it.storage.Delete(context.WithoutCancel(ctx), key); err != nil {
+			slog.Error("failed to delete uploaded original", "key", key, "error", err)
+		}
+	}()
+
+	if info.Size > imaging.MaxFileSize {
+		return nil, imaging.ErrTooLarge
+	}
+	if !imaging.IsAccepted(info.ContentType) {
+		return nil, imaging.ErrUnsupportedFormat
+	}
+
+	reader, _, err := it.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, imaging.MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	return it.save(ctx, id, name, data)
+}
+
+func (it *FileService) save(ctx context.Context, id, name string, data []byte) (*model.File, error) {
+	processed, err := imaging.Process(data)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &model.File{
+		ID:         id,
+		Name:       name,
+		MimeType:   processed.MimeType,
+		Width:      processed.Width,
+		Height:     processed.Height,
+		Renditions: model.Renditions{},
+		CreatedAt:  time.Now(),
+	}
+
+	for _, r := range processed.Renditions {
+		key := file.ID + "/" + r.Size + r.Ext
+		url, err := it.storage.Put(ctx, key, bytes.NewReader(r.Data), int64(len(r.Data)), r.MimeType)
+		if err != nil {
+			it.deleteObjects(ctx, file.Renditions.Keys())
+			return nil, err
+		}
+
+		if file.Renditions[r.Size] == nil {
+			file.Renditions[r.Size] = map[string]model.FileRendition{}
+		}
+		file.Renditions[r.Size][r.Format] = model.FileRendition{
+			URL:      url,
+			Key:      key,
+			MimeType: r.MimeType,
+			Width:    r.Width,
+			Height:   r.Height,
+		}
+	}
+	file.Path = file.Renditions["full"]["jpeg"].URL
+
+	if err := it.repo.Create(ctx, file); err != nil {
+		it.deleteObjects(ctx, file.Renditions.Keys())
+		return nil, err
+	}
+	return file, nil
+}
+
+func (it *FileService) GetAllFiles(ctx context.Context, page model.PageRequest) (model.Page[model.File], error) {
+	return it.repo.GetAll(ctx, page)
 }
 
 func (it *FileService) GetFileByID(ctx context.Context, id string) (*model.File, error) {
 	return it.repo.GetByID(ctx, id)
 }
 
-func (it *FileService) DeleteFile(ctx context.Context, id string) error {
-	return it.repo.Delete(ctx, id)
+// Delete удаляет запись о файле и его объекты в хранилище. Файл, на который ещё ссылаются,
+// не удаляется (409). Объекты, которые не удалось убрать, позже найдёт Sweep.
+func (it *FileService) Delete(ctx context.Context, id string) error {
+	file, err := it.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := it.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	it.deleteObjects(ctx, objectKeys(file))
+	return nil
+}
+
+// objectKeys возвращает имена объектов файла в хранилище
+func objectKeys(file *model.File) []string {
+	if keys := file.Renditions.Keys(); len(keys) > 0 {
+		return keys
+	}
+	// файлы, загруженные до появления вариантов, лежат под последним сегментом URL
+	return []string{path.Base(file.Path)}
+}
+
+// resolveImage связывает картинку сущности с загруженным файлом. По ID файла URL берётся из files.path;
+// старые клиенты присылают только URL — тогда файл ищется по нему, а чужие URL сохраняются как есть.
+func resolveImage(ctx context.Context, files *repository.FileRepository, fileID, url string) (string, string, error) {
+	if fileID != "" {
+		file, err := files.GetByID(ctx, fileID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", puberr.NewPubErr("image file not found").SetHTTPCode(http.StatusNotFound).SetCause(err)
+		}
+		if err != nil {
+			return "", "", err
+		}
+		return file.ID, file.Path, nil
+	}
+
+	if url == "" {
+		return "", "", nil
+	}
+	file, err := files.GetByPath(ctx, url)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", url, nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return file.ID, url, nil
+}
+
+// sanitizeFileName оставляет от имени файла только безопасные для ключа символы
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, path.Base(name))
+
+	name = strings.TrimLeft(name, ".")
+	if name == "" {
+		return "original"
+	}
+	return name
+}
+
+// deleteObjects убирает уже загруженные варианты, если загрузка не завершилась
+func (it *FileService) deleteObjects(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := it.storage.Delete(ctx, key); err != nil {
+			slog.Error("failed to clean up uploaded rendition", "key", key, "error", err)
+		}
+	}
 }
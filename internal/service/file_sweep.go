@@ -0,0 +1,156 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"context"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MinSweepGrace — файлы моложе этого срока не трогаются: клиент мог ещё не успеть сослаться на загрузку
+const MinSweepGrace = time.Hour
+
+// renditionKeyPattern — ключ варианта, который пишет save: <UUID файла>/<размер><расширение>
+var renditionKeyPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}/[a-z]+\.[a-z]+$`)
+
+// sweepPrefixes — префиксы ключей, которые пишет этот сервис: исходники Presign и варианты,
+// ключи которых начинаются с шестнадцатеричной цифры UUID. Остальное в бакете сервису не принадлежит.
+var sweepPrefixes = append([]string{uploadPrefix}, strings.Split("0123456789abcdef", "")...)
+
+// Sweep находит файлы, на которые не ссылается ни одна сущность, и объекты хранилища без записи в files,
+// если они старше grace. В режиме dryRun ничего не удаляется, только возвращается отчёт.
+func (it *FileService) Sweep(ctx context.Context, grace time.Duration, dryRun bool) (*model.SweepReport, error) {
+	if grace < MinSweepGrace {
+		grace = MinSweepGrace
+	}
+	report := &model.SweepReport{
+		DryRun:  dryRun,
+		Before:  time.Now().Add(-grace),
+		Files:   make([]model.File, 0),
+		Objects: make([]string, 0),
+	}
+
+	files, err := it.repo.GetUnreferenced(ctx, report.Before)
+	if err != nil {
+		return nil, err
+	}
+	report.Files = files
+
+	if !dryRun {
+		for i := range files {
+			// на файл могли сослаться после выборки — тогда внешний ключ не даст его удалить
+			if err := it.repo.Delete(ctx, files[i].ID); err != nil {
+				slog.Warn("sweep: file skipped", "id", files[i].ID, "error", err)
+				continue
+			}
+			report.DeletedFiles++
+			report.DeletedObjects += it.deleteSweptObjects(ctx, objectKeys(&files[i]))
+		}
+	}
+
+	orphans, err := it.orphanObjects(ctx, report.Before)
+	if err != nil {
+		return nil, err
+	}
+	report.Objects = orphans
+
+	if !dryRun {
+		report.DeletedObjects += it.deleteSweptObjects(ctx, orphans)
+	}
+	return report, nil
+}
+
+// orphanObjects возвращает объекты хранилища старше before, которые не принадлежат ни одному файлу
+// и на которые не ссылается image_url ни одной сущности. Сюда попадают и исходники presigned-загрузок,
+// для которых так и не вызвали Complete. Проверяются только ключи, которые пишет этот сервис.
+func (it *FileService) orphanObjects(ctx context.Context, before time.Time) ([]string, error) {
+	files, err := it.repo.GetAllObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]struct{})
+	for i := range files {
+		for _, key := range objectKeys(&files[i]) {
+			known[key] = struct{}{}
+		}
+	}
+
+	urls, err := it.repo.GetReferencedURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseURL := it.storage.URL("")
+	for _, url := range urls {
+		if key, ok := strings.CutPrefix(url, baseURL); ok {
+			known[key] = struct{}{}
+		}
+		// URL мог быть выдан с другим адресом хранилища — ключ варианта тогда берётся из двух последних сегментов
+		dir, name := path.Split(url)
+		known[path.Base(dir)+"/"+name] = struct{}{}
+	}
+
+	orphans := make([]string, 0)
+	for _, prefix := range sweepPrefixes {
+		objects, err := it.storage.List(ctx, prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range objects {
+			if !isSweptKey(object.Key) || !object.LastModified.Before(before) {
+				continue
+			}
+			if _, ok := known[object.Key]; ok {
+				continue
+			}
+			orphans = append(orphans, object.Key)
+		}
+	}
+	return orphans, nil
+}
+
+// isSweptKey сообщает, что ключ записан этим сервисом: исходник Presign или вариант файла
+func isSweptKey(key string) bool {
+	return uploadKeyPattern.MatchString(key) || renditionKeyPattern.MatchString(key)
+}
+
+func (it *FileService) deleteSweptObjects(ctx context.Context, keys []string) int {
+	deleted := 0
+	for _, key := range keys {
+		if err := it.storage.Delete(ctx, key); err != nil {
+			slog.Warn("sweep: object skipped", "key", key, "error", err)
+			continue
+		}
+		deleted++
+	}
+	return deleted
+}
+
+// RunSweeper периодически запускает Sweep до отмены ctx
+func (it *FileService) RunSweeper(ctx context.Context, interval, grace time.Duration, dryRun bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := it.Sweep(ctx, grace, dryRun)
+			if err != nil {
+				slog.Error("sweep: failed", "error", err)
+				continue
+			}
+			slog.Info("sweep: done",
+				"dry_run", report.DryRun,
+				"unreferenced_files", len(report.Files),
+				"orphan_objects", len(report.Objects),
+				"deleted_files", report.DeletedFiles,
+				"deleted_objects", report.DeletedObjects,
+			)
+		}
+	}
+}
@@ -3,22 +3,36 @@ package service
 import (
 	"CookFinder.Backend/internal/model"
 	repository "CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
 	"CookFinder.Backend/pkg/uuid"
 	"context"
+	"fmt"
+	"strings"
 )
 
+const maxSuggestions = 20
+
 type IngredientService struct {
-	repo *repository.IngredientRepository
+	repo     *repository.IngredientRepository
+	fileRepo *repository.FileRepository
 }
 
-func NewIngredientService(repo *repository.IngredientRepository) *IngredientService {
-	return &IngredientService{repo: repo}
+func NewIngredientService(repo *repository.IngredientRepository, fileRepo *repository.FileRepository) *IngredientService {
+	return &IngredientService{repo: repo, fileRepo: fileRepo}
 }
 
 func (s *IngredientService) Create(ctx context.Context, ingredient *model.Ingredient) error {
 	if ingredient.ID == "" {
 		ingredient.ID = uuid.V7().String()
 	}
+	if err := validateIngredient(ingredient); err != nil {
+		return err
+	}
+
+	var err error
+	if ingredient.ImageFileID, ingredient.ImageUrl, err = resolveImage(ctx, s.fileRepo, ingredient.ImageFileID, ingredient.ImageUrl); err != nil {
+		return err
+	}
 
 	return s.repo.Create(ctx, ingredient)
 }
@@ -28,13 +42,64 @@ func (s *IngredientService) GetByID(ctx context.Context, id string) (*model.Ingr
 }
 
 func (s *IngredientService) Update(ctx context.Context, model *model.Ingredient) error {
+	if err := validateIngredient(model); err != nil {
+		return err
+	}
+
+	var err error
+	if model.ImageFileID, model.ImageUrl, err = resolveImage(ctx, s.fileRepo, model.ImageFileID, model.ImageUrl); err != nil {
+		return err
+	}
 	return s.repo.Update(ctx, model)
 }
 
-func (s *IngredientService) GetAll(ctx context.Context) ([]model.Ingredient, error) {
-	return s.repo.GetAll(ctx)
+func (s *IngredientService) GetAll(ctx context.Context, page model.PageRequest) (model.Page[model.Ingredient], error) {
+	return s.repo.GetAll(ctx, page)
+}
+
+func (s *IngredientService) Suggest(ctx context.Context, q string, limit int) ([]model.IngredientSuggestion, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, puberr.NewPubErr("q is required")
+	}
+	if limit <= 0 || limit > maxSuggestions {
+		limit = maxSuggestions
+	}
+
+	return s.repo.Suggest(ctx, q, limit)
 }
 
 func (s *IngredientService) Delete(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
+
+func validateIngredient(ingredient *model.Ingredient) error {
+	if ingredient.DensityGPerMl < 0 || ingredient.UnitWeightG < 0 {
+		return puberr.NewPubErr("density and unit weight must not be negative")
+	}
+
+	for _, allergen := range ingredient.Allergens {
+		if !model.IsValidAllergen(allergen) {
+			return puberr.NewPubErr(fmt.Sprintf("unknown allergen %q", allergen))
+		}
+	}
+	if ingredient.Aisle == "" {
+		ingredient.Aisle = model.AisleOther
+	}
+	if !model.IsValidAisle(ingredient.Aisle) {
+		return puberr.NewPubErr(fmt.Sprintf("unknown aisle %q", ingredient.Aisle))
+	}
+
+	// мясо и рыба всегда животного происхождения
+	if ingredient.IsMeat {
+		ingredient.IsAnimal = true
+	}
+
+	n := ingredient.Nutrients
+	for _, v := range []float64{n.Kcal, n.Protein, n.Fat, n.Carbs, n.Fiber, n.Sugar, n.Salt} {
+		if v < 0 {
+			return puberr.NewPubErr("nutrients must not be negative")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/uuid"
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+const (
+	daysInWeek = 7
+	// maxMealPlanRange ограничивает выборку записей по датам
+	maxMealPlanRange = 62
+)
+
+type MealPlanService struct {
+	repo         *repo.MealPlanRepository
+	recipeRepo   *repo.RecipeRepository
+	shoppingList *ShoppingListService
+}
+
+func NewMealPlanService(repo *repo.MealPlanRepository, recipeRepo *repo.RecipeRepository, shoppingList *ShoppingListService) *MealPlanService {
+	return &MealPlanService{
+		repo:         repo,
+		recipeRepo:   recipeRepo,
+		shoppingList: shoppingList,
+	}
+}
+
+// Create добавляет рецепт в план; без servings берётся число порций рецепта
+func (s *MealPlanService) Create(ctx context.Context, entry *model.MealPlanEntry) (*model.MealPlanEntry, error) {
+	if err := s.validateEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	entry.ID = uuid.V7().String()
+	entry.CreatedAt = time.Now()
+	if err := s.repo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, entry.ID)
+}
+
+func (s *MealPlanService) Update(ctx context.Context, entry *model.MealPlanEntry) (*model.MealPlanEntry, error) {
+	if _, err := s.getOwned(ctx, entry.UserID, entry.ID); err != nil {
+		return nil, err
+	}
+	if err := s.validateEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, entry.ID)
+}
+
+func (s *MealPlanService) Delete(ctx context.Context, userID, id string) error {
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// GetRange возвращает записи плана с from по to включительно
+func (s *MealPlanService) GetRange(ctx context.Context, userID string, from, to time.Time) ([]model.MealPlanEntry, error) {
+	from, to = truncateDay(from), truncateDay(to)
+	if to.Before(from) {
+		return nil, puberr.NewPubErr("to must not be before from")
+	}
+	if to.Sub(from) > maxMealPlanRange*24*time.Hour {
+		return nil, puberr.NewPubErr(fmt.Sprintf("date range must not exceed %d days", maxMealPlanRange))
+	}
+
+	entries, err := s.repo.GetByUserID(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	sortMealPlanEntries(entries)
+	return entries, nil
+}
+
+// Week собирает план на семь дней начиная со start с суммой пищевой ценности по дням.
+// Нулевой start — понедельник текущей недели.
+func (s *MealPlanService) Week(ctx context.Context, userID string, start time.Time) (*model.MealPlanWeek, error) {
+	start = weekStart(start)
+	entries, err := s.GetRange(ctx, userID, start, start.AddDate(0, 0, daysInWeek-1))
+	if err != nil {
+		return nil, err
+	}
+
+	return newMealPlanWeek(start, entries), nil
+}
+
+// newMealPlanWeek раскладывает отсортированные записи по дням недели и суммирует пищевую ценность
+func newMealPlanWeek(start time.Time, entries []model.MealPlanEntry) *model.MealPlanWeek {
+	week := &model.MealPlanWeek{Start: start, Days: make([]model.MealPlanDay, daysInWeek)}
+	for i := range week.Days {
+		week.Days[i] = model.MealPlanDay{Date: start.AddDate(0, 0, i), Entries: make([]model.MealPlanEntry, 0)}
+	}
+	for _, entry := range entries {
+		i := int(truncateDay(entry.Date).Sub(start).Hours() / 24)
+		if i < 0 || i >= daysInWeek {
+			continue
+		}
+		day := &week.Days[i]
+		day.Entries = append(day.Entries, entry)
+		day.Totals.Add(entry.MealNutrition)
+		week.Totals.Add(entry.MealNutrition)
+	}
+	return week
+}
+
+// WeekShoppingList составляет список покупок по всем рецептам недели с учётом порций
+func (s *MealPlanService) WeekShoppingList(ctx context.Context, userID string, start time.Time) (*model.ShoppingList, error) {
+	start = weekStart(start)
+	entries, err := s.GetRange(ctx, userID, start, start.AddDate(0, 0, daysInWeek-1))
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, puberr.NewPubErr("meal plan for this week is empty")
+	}
+
+	// повторы рецепта ShoppingListService сложит по порциям
+	recipes := make([]model.ShoppingListRecipe, 0, len(entries))
+	for _, entry := range entries {
+		recipes = append(recipes, model.ShoppingListRecipe{RecipeID: entry.RecipeID, Servings: entry.Servings})
+	}
+
+	name := "Week of " + start.Format(time.DateOnly)
+	return s.shoppingList.Create(ctx, userID, name, recipes)
+}
+
+func (s *MealPlanService) validateEntry(ctx context.Context, entry *model.MealPlanEntry) error {
+	if entry.Date.IsZero() {
+		return puberr.NewPubErr("date is required")
+	}
+	entry.Date = truncateDay(entry.Date)
+	if !model.IsValidMealSlot(entry.Slot) {
+		return puberr.NewPubErr(fmt.Sprintf("unknown meal slot %q", entry.Slot))
+	}
+	if entry.Servings < 0 {
+		return puberr.NewPubErr("servings must be positive")
+	}
+
+	recipes, err := s.recipeRepo.GetByIDs(ctx, []string{entry.RecipeID})
+	if err != nil {
+		return err
+	}
+	if recipes[0] == nil {
+		return puberr.NewPubErr("recipe not found").SetHTTPCode(http.StatusNotFound)
+	}
+	if entry.Servings == 0 {
+		entry.Servings = max(recipes[0].Recipe.Servings, 1)
+	}
+	return nil
+}
+
+// getOwned скрывает чужие записи так же, как несуществующие
+func (s *MealPlanService) getOwned(ctx context.Context, userID, id string) (*model.MealPlanEntry, error) {
+	entry, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, puberr.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if entry.UserID != userID {
+		return nil, puberr.ErrNotFound
+	}
+	return entry, nil
+}
+
+// sortMealPlanEntries упорядочивает записи по дате и приёму пищи, внутри приёма — по времени добавления
+func sortMealPlanEntries(entries []model.MealPlanEntry) {
+	slices.SortStableFunc(entries, func(a, b model.MealPlanEntry) int {
+		return cmp.Or(
+			a.Date.Compare(b.Date),
+			cmp.Compare(slices.Index(model.MealSlots, a.Slot), slices.Index(model.MealSlots, b.Slot)),
+		)
+	})
+}
+
+// truncateDay оставляет от времени календарную дату в UTC, как её хранит колонка DATE
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart возвращает start без времени, а для нулевого start — понедельник текущей недели
+func weekStart(start time.Time) time.Time {
+	if !start.IsZero() {
+		return truncateDay(start)
+	}
+	today := truncateDay(time.Now())
+	offset := (int(today.Weekday()) + 6) % 7
+	return today.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/uuid"
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// maxPlanCandidates ограничивает число рецептов, из которых составляется план
+	maxPlanCandidates   = 500
+	defaultNoRepeatDays = 3
+	maxEnergyPerDay     = 10000
+
+	// веса составляющих оценки рецепта; основная — отклонение калорийности от цели приёма пищи
+	macroWeight    = 0.5
+	leftoverWeight = 0.3
+	mealTypeWeight = 0.2
+	// jitterWeight — случайная добавка от seed, чтобы разные seed давали разные планы из похожих рецептов
+	jitterWeight = 0.05
+)
+
+// slotEnergyShare — доля дневной энергии, приходящаяся на приём пищи
+var slotEnergyShare = map[string]float64{
+	model.MealSlotBreakfast: 0.25,
+	model.MealSlotLunch:     0.35,
+	model.MealSlotDinner:    0.30,
+	model.MealSlotSnack:     0.10,
+}
+
+// Generate предлагает план на неделю из существующих рецептов. Диета и исключённые ингредиенты
+// соблюдаются строго, калорийность и макросы — насколько позволяют рецепты. Рецепт не повторяется
+// в пределах NoRepeatDays дней, пока есть из чего выбирать, а при равных условиях предпочитаются
+// рецепты с ингредиентами, которые уже куплены для предыдущих блюд плана. С Save план заменяет
+// записи календаря в тех же приёмах пищи этой недели.
+func (s *MealPlanService) Generate(ctx context.Context, userID string, c model.MealPlanConstraints) (*model.GeneratedMealPlan, error) {
+	if err := normalizeConstraints(&c); err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.recipeRepo.GetCandidates(ctx, model.RecipeFilter{
+		Diet:               c.Diet,
+		ExcludeAllergens:   c.ExcludeAllergens,
+		ExcludeIngredients: c.ExcludeIngredients,
+	}, c.Seed, maxPlanCandidates)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, puberr.NewPubErr("no recipes match the constraints")
+	}
+
+	planner := &mealPlanner{
+		constraints: c,
+		candidates:  candidates,
+		rng:         rand.New(rand.NewPCG(uint64(c.Seed), uint64(c.Seed))),
+		lastUsed:    make(map[string]int),
+		bought:      make(map[string]bool),
+	}
+	entries := planner.plan(userID)
+
+	if c.Save {
+		now := time.Now()
+		for i := range entries {
+			entries[i].ID = uuid.V7().String()
+			entries[i].CreatedAt = now
+		}
+		// прежние блюда в этих приёмах пищи на неделе заменяются, другие приёмы пищи не трогаются
+		if err := s.repo.ReplaceSlots(ctx, userID, c.Start, c.Start.AddDate(0, 0, daysInWeek), c.Slots, entries); err != nil {
+			return nil, err
+		}
+	}
+
+	target := model.MealNutrition{Energy: c.EnergyPerDay}
+	if c.ProteinPercent+c.FatPercent+c.CarbsPercent > 0 {
+		target.Protein = c.EnergyPerDay * c.ProteinPercent / 100 / kcalPerGramProtein
+		target.Fat = c.EnergyPerDay * c.FatPercent / 100 / kcalPerGramFat
+		target.Carbs = c.EnergyPerDay * c.CarbsPercent / 100 / kcalPerGramCarbs
+	}
+	scaleMealNutrition(&target, float64(c.Servings))
+
+	return &model.GeneratedMealPlan{
+		MealPlanWeek: *newMealPlanWeek(c.Start, entries),
+		Seed:         c.Seed,
+		Target:       target,
+		Saved:        c.Save,
+	}, nil
+}
+
+// ккал в грамме белков, жиров и углеводов
+const (
+	kcalPerGramProtein = 4
+	kcalPerGramFat     = 9
+	kcalPerGramCarbs   = 4
+)
+
+func normalizeConstraints(c *model.MealPlanConstraints) error {
+	c.Start = weekStart(c.Start)
+
+	if len(c.Slots) == 0 {
+		c.Slots = []string{model.MealSlotBreakfast, model.MealSlotLunch, model.MealSlotDinner}
+	}
+	for _, slot := range c.Slots {
+		if !model.IsValidMealSlot(slot) {
+			return puberr.NewPubErr(fmt.Sprintf("unknown meal slot %q", slot))
+		}
+	}
+	// приёмы пищи идут в порядке дня, повторы убираются
+	c.Slots = slices.DeleteFunc(slices.Clone(model.MealSlots), func(slot string) bool { return !slices.Contains(c.Slots, slot) })
+
+	if c.Servings == 0 {
+		c.Servings = 1
+	}
+	if c.Servings < 0 {
+		return puberr.NewPubErr("servings must be positive")
+	}
+	if c.EnergyPerDay <= 0 || c.EnergyPerDay > maxEnergyPerDay {
+		return puberr.NewPubErr(fmt.Sprintf("energy_per_day must be between 1 and %d", maxEnergyPerDay))
+	}
+
+	percents := []float64{c.ProteinPercent, c.FatPercent, c.CarbsPercent}
+	if slices.ContainsFunc(percents, func(p float64) bool { return p < 0 }) {
+		return puberr.NewPubErr("macro percentages must not be negative")
+	}
+	if sum := c.ProteinPercent + c.FatPercent + c.CarbsPercent; sum > 0 && math.Abs(sum-100) > 1 {
+		return puberr.NewPubErr("macro percentages must add up to 100")
+	}
+
+	for _, allergen := range c.ExcludeAllergens {
+		if !model.IsValidAllergen(allergen) {
+			return puberr.NewPubErr(fmt.Sprintf("unknown allergen %q", allergen))
+		}
+	}
+	c.ExcludeIngredients = uniqueIDs(c.ExcludeIngredients)
+
+	if c.NoRepeatDays < 0 {
+		return puberr.NewPubErr("no_repeat_days must not be negative")
+	}
+	if c.NoRepeatDays == 0 {
+		c.NoRepeatDays = defaultNoRepeatDays
+	}
+
+	// без seed план всё равно воспроизводим: выбранный seed возвращается в ответе
+	if c.Seed == 0 {
+		c.Seed = time.Now().UnixNano()
+	}
+	return nil
+}
+
+// mealPlanner жадно выбирает рецепт на каждый приём пищи по порядку дней
+type mealPlanner struct {
+	constraints model.MealPlanConstraints
+	candidates  []model.RecipeCategoryIngredients
+	rng         *rand.Rand
+	lastUsed    map[string]int  // ID рецепта -> день, когда он последний раз попал в план
+	bought      map[string]bool // ингредиенты, уже нужные для предыдущих блюд
+}
+
+func (p *mealPlanner) plan(userID string) []model.MealPlanEntry {
+	c := p.constraints
+	var shares float64
+	for _, slot := range c.Slots {
+		shares += slotEnergyShare[slot]
+	}
+
+	entries := make([]model.MealPlanEntry, 0, daysInWeek*len(c.Slots))
+	for day := 0; day < daysInWeek; day++ {
+		for _, slot := range c.Slots {
+			target := c.EnergyPerDay * slotEnergyShare[slot] / shares
+			recipe := p.pick(day, slot, target)
+
+			entries = append(entries, model.MealPlanEntry{
+				UserID:         userID,
+				Date:           c.Start.AddDate(0, 0, day),
+				Slot:           slot,
+				RecipeID:       recipe.Recipe.ID,
+				Servings:       c.Servings,
+				RecipeTitle:    recipe.Recipe.Title,
+				RecipeImageURL: recipe.Recipe.ImageURL,
+				MealNutrition:  mealNutrition(&recipe.Recipe, c.Servings),
+			})
+
+			p.lastUsed[recipe.Recipe.ID] = day
+			for _, ing := range recipe.Ingredients {
+				p.bought[ing.ID] = true
+			}
+		}
+	}
+	return entries
+}
+
+// pick выбирает рецепт с наименьшей оценкой среди не повторявшихся в последние NoRepeatDays дней.
+// Если таких нет, берётся тот, что использовался раньше остальных.
+func (p *mealPlanner) pick(day int, slot string, target float64) *model.RecipeCategoryIngredients {
+	var best, fallback *model.RecipeCategoryIngredients
+	bestScore, fallbackScore := math.Inf(1), math.Inf(1)
+	fallbackDay := day
+
+	for i := range p.candidates {
+		recipe := &p.candidates[i]
+		// оценка считается для всех кандидатов, чтобы последовательность случайных чисел не зависела от истории
+		score := p.score(recipe, slot, target)
+
+		last, used := p.lastUsed[recipe.Recipe.ID]
+		if !used || day-last >= p.constraints.NoRepeatDays {
+			if score < bestScore {
+				best, bestScore = recipe, score
+			}
+			continue
+		}
+		if last < fallbackDay || (last == fallbackDay && score < fallbackScore) {
+			fallback, fallbackScore, fallbackDay = recipe, score, last
+		}
+	}
+
+	if best != nil {
+		return best
+	}
+	return fallback
+}
+
+// score — чем меньше, тем лучше рецепт подходит приёму пищи
+func (p *mealPlanner) score(recipe *model.RecipeCategoryIngredients, slot string, target float64) float64 {
+	perServing := mealNutrition(&recipe.Recipe, 1)
+
+	// частично рассчитанная пищевая ценность занижена, поэтому считается неизвестной, как и нулевая
+	if perServing.Energy > 0 && len(recipe.Recipe.NutritionSkipped) == 0 {
+		score := math.Abs(perServing.Energy-target) / target
+		score += macroWeight * p.macroDeviation(perServing)
+		return score + p.preference(recipe, slot)
+	}
+	return 1 + macroWeight*p.macroDeviation(model.MealNutrition{}) + p.preference(recipe, slot)
+}
+
+// preference — поправка оценки, не зависящая от пищевой ценности: остатки ингредиентов, вид приёма пищи и случайная добавка
+func (p *mealPlanner) preference(recipe *model.RecipeCategoryIngredients, slot string) float64 {
+	var score float64
+
+	if len(recipe.Ingredients) > 0 {
+		reused := 0
+		for _, ing := range recipe.Ingredients {
+			if p.bought[ing.ID] {
+				reused++
+			}
+		}
+		score -= leftoverWeight * float64(reused) / float64(len(recipe.Ingredients))
+	}
+
+	score += mealTypeWeight * mealTypeMismatch(recipe.Tags, slot)
+	score += jitterWeight * p.rng.Float64()
+	return score
+}
+
+// macroDeviation сравнивает доли энергии из белков, жиров и углеводов с заданными: 0 — совпадают, 1 — максимально далеки
+func (p *mealPlanner) macroDeviation(n model.MealNutrition) float64 {
+	c := p.constraints
+	if c.ProteinPercent+c.FatPercent+c.CarbsPercent == 0 {
+		return 0
+	}
+
+	protein, fat, carbs := n.Protein*kcalPerGramProtein, n.Fat*kcalPerGramFat, n.Carbs*kcalPerGramCarbs
+	total := protein + fat + carbs
+	if total == 0 {
+		return 1
+	}
+	return (math.Abs(protein/total*100-c.ProteinPercent) +
+		math.Abs(fat/total*100-c.FatPercent) +
+		math.Abs(carbs/total*100-c.CarbsPercent)) / 200
+}
+
+// mealTypeMismatch: -1, если тег вида meal_type совпадает с приёмом пищи (например, тег «breakfast»),
+// 1, если у рецепта есть только другие такие теги, и 0, если их нет
+func mealTypeMismatch(tags []model.Tag, slot string) float64 {
+	hasMealType := false
+	for _, tag := range tags {
+		if tag.Kind != model.TagMealType {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(tag.Name), slot) {
+			return -1
+		}
+		hasMealType = true
+	}
+	if hasMealType {
+		return 1
+	}
+	return 0
+}
+
+// mealNutrition пересчитывает пищевую ценность рецепта (она хранится на recipe.Servings порций) на servings порций
+func mealNutrition(recipe *model.Recipe, servings int) model.MealNutrition {
+	n := model.MealNutrition{
+		Energy:  float64(recipe.Energy),
+		Fat:     recipe.Fat,
+		Protein: recipe.Protein,
+		Carbs:   recipe.Carbs,
+		Fiber:   recipe.Fiber,
+		Sugar:   recipe.Sugar,
+		Sodium:  recipe.Sodium,
+	}
+	scaleMealNutrition(&n, float64(servings)/float64(max(recipe.Servings, 1)))
+	return n
+}
+
+func scaleMealNutrition(n *model.MealNutrition, factor float64) {
+	n.Energy *= factor
+	n.Fat *= factor
+	n.Protein *= factor
+	n.Carbs *= factor
+	n.Fiber *= factor
+	n.Sugar *= factor
+	n.Sodium *= factor
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestPlanner(c model.MealPlanConstraints, candidates []model.RecipeCategoryIngredients) *mealPlanner {
+	return &mealPlanner{
+		constraints: c,
+		candidates:  candidates,
+		rng:         rand.New(rand.NewPCG(uint64(c.Seed), uint64(c.Seed))),
+		lastUsed:    make(map[string]int),
+		bought:      make(map[string]bool),
+	}
+}
+
+// testCandidate — рецепт на одну порцию с заданной калорийностью и ингредиентами
+func testCandidate(id string, energy int, ingredients ...string) model.RecipeCategoryIngredients {
+	recipe := model.RecipeCategoryIngredients{
+		Recipe: model.Recipe{ID: id, Title: id, Servings: 1, Energy: energy},
+	}
+	for _, ing := range ingredients {
+		recipe.Ingredients = append(recipe.Ingredients, model.IngredientWithAmount{ID: ing})
+	}
+	return recipe
+}
+
+func TestMealPlannerPlan(t *testing.T) {
+	varied := make([]model.RecipeCategoryIngredients, 0, 10)
+	for i := 0; i < 10; i++ {
+		id := fmt.Sprintf("r%d", i)
+		varied = append(varied, testCandidate(id, 300+60*i, "ing-"+id))
+	}
+
+	// одинаковая калорийность: выбор решают только остатки ингредиентов и случайная добавка
+	leftovers := []model.RecipeCategoryIngredients{
+		testCandidate("a1", 600, "a"),
+		testCandidate("a2", 600, "a"),
+		testCandidate("b1", 600, "b"),
+		testCandidate("b2", 600, "b"),
+		testCandidate("c1", 600, "c"),
+		testCandidate("c2", 600, "c"),
+	}
+
+	// без ингредиентов остатки не влияют на выбор, остаётся калорийность и случайная добавка
+	energies := make([]model.RecipeCategoryIngredients, 0, 12)
+	for energy := 200; energy <= 1300; energy += 100 {
+		energies = append(energies, testCandidate(fmt.Sprintf("e%d", energy), energy))
+	}
+
+	// одинаковая калорийность, разные доли белков, жиров и углеводов; balanced совпадает с 30/30/40
+	macros := []model.RecipeCategoryIngredients{
+		testMacroCandidate("protein", 2000, 400, 11, 80),
+		testMacroCandidate("fat", 2000, 50, 180, 50),
+		testMacroCandidate("balanced", 2000, 150, 66.7, 200),
+		testMacroCandidate("carbs", 2000, 50, 22, 400),
+	}
+
+	tests := []struct {
+		name           string
+		constraints    model.MealPlanConstraints
+		candidates     []model.RecipeCategoryIngredients
+		checkLeftovers bool
+		checkEnergy    bool
+		checkMacros    bool
+	}{
+		{
+			name: "three meals a day",
+			constraints: model.MealPlanConstraints{
+				Slots:        []string{model.MealSlotBreakfast, model.MealSlotLunch, model.MealSlotDinner},
+				Servings:     1,
+				EnergyPerDay: 2000,
+				NoRepeatDays: 3,
+				Seed:         42,
+			},
+			candidates: varied,
+		},
+		{
+			name: "leftovers preferred",
+			constraints: model.MealPlanConstraints{
+				Slots:        []string{model.MealSlotDinner},
+				Servings:     2,
+				EnergyPerDay: 2000,
+				NoRepeatDays: 2,
+				Seed:         7,
+			},
+			candidates:     leftovers,
+			checkLeftovers: true,
+		},
+		{
+			name: "closest to slot energy",
+			constraints: model.MealPlanConstraints{
+				Slots:        []string{model.MealSlotBreakfast, model.MealSlotLunch, model.MealSlotDinner},
+				Servings:     1,
+				EnergyPerDay: 2000,
+				NoRepeatDays: 2,
+				Seed:         11,
+			},
+			candidates:  energies,
+			checkEnergy: true,
+		},
+		{
+			name: "closest to macro split",
+			constraints: model.MealPlanConstraints{
+				Slots:          []string{model.MealSlotDinner},
+				Servings:       1,
+				EnergyPerDay:   2000,
+				ProteinPercent: 30,
+				FatPercent:     30,
+				CarbsPercent:   40,
+				NoRepeatDays:   1,
+				Seed:           3,
+			},
+			candidates:  macros,
+			checkMacros: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.constraints.Start = time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+
+			first := newTestPlanner(tt.constraints, tt.candidates).plan("user")
+			second := newTestPlanner(tt.constraints, tt.candidates).plan("user")
+			if !reflect.DeepEqual(first, second) {
+				t.Fatal("plans generated with the same seed differ")
+			}
+			if want := daysInWeek * len(tt.constraints.Slots); len(first) != want {
+				t.Fatalf("got %d entries, want %d", len(first), want)
+			}
+
+			var shares float64
+			for _, slot := range tt.constraints.Slots {
+				shares += slotEnergyShare[slot]
+			}
+			planner := newTestPlanner(tt.constraints, tt.candidates)
+
+			byID := make(map[string]model.RecipeCategoryIngredients, len(tt.candidates))
+			for _, c := range tt.candidates {
+				byID[c.Recipe.ID] = c
+			}
+			lastUsed := make(map[string]int)
+			bought := make(map[string]bool)
+			for _, entry := range first {
+				day := int(entry.Date.Sub(tt.constraints.Start).Hours() / 24)
+				if last, ok := lastUsed[entry.RecipeID]; ok && day-last < tt.constraints.NoRepeatDays {
+					t.Errorf("%s repeated on day %d after day %d", entry.RecipeID, day, last)
+				}
+
+				if tt.checkLeftovers {
+					best := 0.0
+					for _, c := range tt.candidates {
+						if last, ok := lastUsed[c.Recipe.ID]; ok && day-last < tt.constraints.NoRepeatDays {
+							continue
+						}
+						best = max(best, reusedShare(c, bought))
+					}
+					if got := reusedShare(byID[entry.RecipeID], bought); got < best {
+						t.Errorf("day %d: %s reuses %.2f of ingredients, a candidate reusing %.2f was available", day, entry.RecipeID, got, best)
+					}
+				}
+
+				// случайная добавка может перевесить только разницу меньше jitterWeight
+				if tt.checkEnergy || tt.checkMacros {
+					target := tt.constraints.EnergyPerDay * slotEnergyShare[entry.Slot] / shares
+					deviation := func(c model.RecipeCategoryIngredients) float64 {
+						n := mealNutrition(&c.Recipe, 1)
+						if tt.checkEnergy {
+							return math.Abs(n.Energy-target) / target
+						}
+						return macroWeight * planner.macroDeviation(n)
+					}
+
+					best := math.Inf(1)
+					for _, c := range tt.candidates {
+						if last, ok := lastUsed[c.Recipe.ID]; ok && day-last < tt.constraints.NoRepeatDays {
+							continue
+						}
+						best = min(best, deviation(c))
+					}
+					if got := deviation(byID[entry.RecipeID]); got > best+jitterWeight {
+						t.Errorf("day %d %s: %s deviates by %.3f, a candidate deviating by %.3f was available", day, entry.Slot, entry.RecipeID, got, best)
+					}
+				}
+
+				lastUsed[entry.RecipeID] = day
+				for _, ing := range byID[entry.RecipeID].Ingredients {
+					bought[ing.ID] = true
+				}
+			}
+		})
+	}
+}
+
+// testMacroCandidate — рецепт на одну порцию с заданной калорийностью и граммами белков, жиров и углеводов
+func testMacroCandidate(id string, energy int, protein, fat, carbs float64) model.RecipeCategoryIngredients {
+	recipe := testCandidate(id, energy)
+	recipe.Recipe.Protein, recipe.Recipe.Fat, recipe.Recipe.Carbs = protein, fat, carbs
+	return recipe
+}
+
+func reusedShare(recipe model.RecipeCategoryIngredients, bought map[string]bool) float64 {
+	reused := 0
+	for _, ing := range recipe.Ingredients {
+		if bought[ing.ID] {
+			reused++
+		}
+	}
+	return float64(reused) / float64(len(recipe.Ingredients))
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+	"fmt"
+	"math"
+)
+
+// waterDensity подставляется для объёмных ингредиентов без указанной плотности
+const waterDensity = 1.0
+
+// sodiumMgPerSaltGram — натрий в грамме поваренной соли (39,34 %), в миллиграммах
+const sodiumMgPerSaltGram = 393.4
+
+// computeNutrition считает пищевую ценность всего рецепта по количествам ингредиентов.
+// Единицы уже должны быть приведены к каноническим кодам. Ингредиенты, которые нельзя
+// перевести в граммы, не учитываются и перечисляются в Skipped.
+func (s *RecipeService) computeNutrition(ctx context.Context, units *unitRegistry, ingredients []model.RecipeIngredient) (model.Nutrition, error) {
+	total := model.Nutrition{Skipped: []string{}}
+	if len(ingredients) == 0 {
+		return total, nil
+	}
+
+	ids := make([]string, len(ingredients))
+	for i, ing := range ingredients {
+		ids[i] = ing.IngredientID
+	}
+	found, err := s.ingredientRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return total, err
+	}
+	byID := make(map[string]model.Ingredient, len(found))
+	for _, ing := range found {
+		byID[ing.ID] = ing
+	}
+
+	for _, ri := range ingredients {
+		ingredient, ok := byID[ri.IngredientID]
+		if !ok {
+			return total, puberr.NewPubErr(fmt.Sprintf("unknown ingredient %q", ri.IngredientID))
+		}
+
+		grams, ok := ingredientGrams(units, ri, ingredient)
+		if !ok {
+			// штуки без известной массы одной штуки в расчёт не попадают
+			total.Skipped = append(total.Skipped, ri.IngredientID)
+			continue
+		}
+
+		factor := grams / 100
+		total.Energy += ingredient.Kcal * factor
+		total.Protein += ingredient.Protein * factor
+		total.Fat += ingredient.Fat * factor
+		total.Carbs += ingredient.Carbs * factor
+		total.Fiber += ingredient.Fiber * factor
+		total.Sugar += ingredient.Sugar * factor
+		total.Salt += ingredient.Salt * factor
+	}
+	total.Skipped = uniqueIDs(total.Skipped)
+	return total, nil
+}
+
+// ingredientGrams переводит количество ингредиента рецепта в граммы
+func ingredientGrams(units *unitRegistry, ri model.RecipeIngredient, ingredient model.Ingredient) (float64, bool) {
+	unit, err := units.resolve(ri.Unit)
+	if err != nil {
+		return 0, false
+	}
+
+	base := ri.Amount * unit.ToBase
+	switch unit.Dimension {
+	case model.DimensionMass:
+		return base, true
+	case model.DimensionVolume:
+		density := ingredient.DensityGPerMl
+		if density == 0 {
+			density = waterDensity
+		}
+		return base * density, true
+	case model.DimensionCount:
+		if ingredient.UnitWeightG == 0 {
+			return 0, false
+		}
+		return base * ingredient.UnitWeightG, true
+	}
+	return 0, false
+}
+
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
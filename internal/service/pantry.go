@@ -0,0 +1,323 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/uuid"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	defaultExpiringDays = 3
+	maxExpiringDays     = 30
+)
+
+type PantryService struct {
+	repo           *repo.PantryRepository
+	recipeRepo     *repo.RecipeRepository
+	ingredientRepo *repo.IngredientRepository
+	unitRepo       *repo.UnitRepository
+}
+
+func NewPantryService(
+	repo *repo.PantryRepository,
+	recipeRepo *repo.RecipeRepository,
+	ingredientRepo *repo.IngredientRepository,
+	unitRepo *repo.UnitRepository,
+) *PantryService {
+	return &PantryService{
+		repo:           repo,
+		recipeRepo:     recipeRepo,
+		ingredientRepo: ingredientRepo,
+		unitRepo:       unitRepo,
+	}
+}
+
+func (s *PantryService) GetAll(ctx context.Context, userID string) ([]model.PantryItem, error) {
+	return s.repo.GetByUserID(ctx, userID)
+}
+
+func (s *PantryService) GetByID(ctx context.Context, userID, id string) (*model.PantryItem, error) {
+	return s.getOwned(ctx, userID, id)
+}
+
+func (s *PantryService) Create(ctx context.Context, item *model.PantryItem) (*model.PantryItem, error) {
+	if err := s.validateItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	item.ID = uuid.V7().String()
+	item.CreatedAt = time.Now()
+	if err := s.repo.Create(ctx, item); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, item.ID)
+}
+
+func (s *PantryService) Update(ctx context.Context, item *model.PantryItem) (*model.PantryItem, error) {
+	if _, err := s.getOwned(ctx, item.UserID, item.ID); err != nil {
+		return nil, err
+	}
+	if err := s.validateItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, item.ID)
+}
+
+func (s *PantryService) Delete(ctx context.Context, userID, id string) error {
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Expiring возвращает рецепты, в которых используются запасы со сроком годности в ближайшие days дней,
+// начиная с тех, что испортятся раньше
+func (s *PantryService) Expiring(ctx context.Context, userID string, days, limit int) ([]model.ExpiringMatch, error) {
+	if days == 0 {
+		days = defaultExpiringDays
+	}
+	if days < 0 || days > maxExpiringDays {
+		return nil, puberr.NewPubErr(fmt.Sprintf("days must be between 1 and %d", maxExpiringDays))
+	}
+
+	today := truncateDay(time.Now())
+	search := model.ExpiringSearch{
+		UserID: userID,
+		Today:  today,
+		Until:  today.AddDate(0, 0, days),
+		Limit:  limit,
+	}
+
+	matches, err := s.recipeRepo.FindByExpiring(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// для каждого ингредиента — самый ранний срок среди неиспорченных партий; nil — срок не указан
+	expiry := make(map[string]*time.Time, len(items))
+	for _, item := range items {
+		if item.ExpiresOn != nil && item.ExpiresOn.Before(today) {
+			continue
+		}
+		current, ok := expiry[item.IngredientID]
+		switch {
+		case !ok:
+			expiry[item.IngredientID] = item.ExpiresOn
+		case item.ExpiresOn == nil:
+		case current == nil || item.ExpiresOn.Before(*current):
+			expiry[item.IngredientID] = item.ExpiresOn
+		}
+	}
+
+	for i := range matches {
+		missing := make([]model.IngredientWithAmount, 0)
+		expiring := make([]model.IngredientWithAmount, 0)
+		for _, ing := range matches[i].Ingredients {
+			expiresOn, ok := expiry[ing.ID]
+			if !ok {
+				missing = append(missing, ing)
+				continue
+			}
+			if expiresOn != nil && !expiresOn.After(search.Until) {
+				expiring = append(expiring, ing)
+			}
+		}
+		matches[i].Missing = missing
+		matches[i].Expiring = expiring
+	}
+	return matches, nil
+}
+
+// Cooked списывает из запасов ингредиенты рецепта на servings порций (0 — порции рецепта).
+// Количество переводится в единицу партии; сначала расходуются партии, которые испортятся раньше,
+// просроченные не трогаются.
+// Ингредиенты, которых нет или которые нельзя перевести в единицу партии, остаются недосписанными в отчёте.
+func (s *PantryService) Cooked(ctx context.Context, userID, recipeID string, servings int) (*model.CookedReport, error) {
+	if servings < 0 {
+		return nil, puberr.NewPubErr("servings must be positive")
+	}
+
+	recipe, err := s.recipeRepo.GetByID(ctx, recipeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, puberr.ErrNotFound
+		}
+		return nil, err
+	}
+
+	base := max(recipe.Recipe.Servings, 1)
+	if servings == 0 {
+		servings = base
+	}
+	factor := float64(servings) / float64(base)
+
+	ids := make([]string, 0, len(recipe.Ingredients))
+	for _, ing := range recipe.Ingredients {
+		ids = append(ids, ing.ID)
+	}
+	ids = uniqueIDs(ids)
+
+	ingredients, err := s.ingredientRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]model.Ingredient, len(ingredients))
+	for _, ing := range ingredients {
+		byID[ing.ID] = ing
+	}
+
+	units, err := loadUnits(ctx, s.unitRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.CookedReport{
+		RecipeID: recipeID,
+		Servings: servings,
+		Usage:    make([]model.PantryUsage, 0, len(recipe.Ingredients)),
+	}
+	err = s.repo.Consume(ctx, userID, ids, truncateDay(time.Now()), func(items []model.PantryItem) {
+		for _, ing := range recipe.Ingredients {
+			usage := model.PantryUsage{
+				IngredientID: ing.ID,
+				Name:         ing.Name,
+				Required:     ing.Amount * factor,
+				Unit:         ing.Unit,
+			}
+			consumeFromPantry(units, byID[ing.ID], &usage, items)
+			usage.Required = roundConverted(usage.Required)
+			usage.Consumed = min(roundConverted(usage.Consumed), usage.Required)
+			report.Usage = append(report.Usage, usage)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+const (
+	// pantryPrecision — точность хранения количества (NUMERIC(10, 3)); меньшие остатки считаются израсходованными
+	pantryPrecision = 1000
+	// maxNumericAmount — NUMERIC(10, 3) вмещает семь знаков до запятой
+	maxNumericAmount = 1e7
+)
+
+// numericAmount округляет количество так же, как колонка NUMERIC(10, 3). false — если округлённое
+// значение не положительно или не помещается в колонку: иначе запись упадёт на CHECK или переполнении.
+func numericAmount(value float64) (float64, bool) {
+	rounded := math.Round(value*pantryPrecision) / pantryPrecision
+	return rounded, rounded > 0 && rounded < maxNumericAmount
+}
+
+// consumeFromPantry уменьшает партии ингредиента usage.IngredientID, пока не наберётся usage.Required,
+// и записывает в usage.Consumed, сколько удалось списать в единице рецепта
+func consumeFromPantry(units *unitRegistry, ingredient model.Ingredient, usage *model.PantryUsage, items []model.PantryItem) {
+	for i := range items {
+		item := &items[i]
+		remaining := usage.Required - usage.Consumed
+		if remaining <= 0 {
+			return
+		}
+		if item.IngredientID != usage.IngredientID || item.Quantity <= 0 {
+			continue
+		}
+
+		need, ok := convertPantryAmount(units, remaining, usage.Unit, item.Unit, ingredient)
+		if !ok || need <= 0 {
+			continue
+		}
+
+		taken := min(need, item.Quantity)
+		item.Quantity = math.Round((item.Quantity-taken)*pantryPrecision) / pantryPrecision
+		// обратно в единицу рецепта — пропорционально, чтобы не переводить единицы второй раз
+		usage.Consumed += remaining * taken / need
+	}
+}
+
+// convertPantryAmount переводит количество между единицами рецепта и запаса. Помимо convertAmount
+// умеет переводить штуки в массу и обратно по массе одной штуки.
+func convertPantryAmount(units *unitRegistry, amount float64, from, to string, ingredient model.Ingredient) (float64, bool) {
+	if from == to {
+		return amount, true
+	}
+	fromUnit, err := units.resolve(from)
+	if err != nil {
+		return 0, false
+	}
+	toUnit, err := units.resolve(to)
+	if err != nil {
+		return 0, false
+	}
+
+	if converted, err := convertAmount(amount, fromUnit, toUnit, ingredient.DensityGPerMl); err == nil {
+		return converted, true
+	}
+	if ingredient.UnitWeightG <= 0 {
+		return 0, false
+	}
+	switch {
+	case fromUnit.Dimension == model.DimensionCount && toUnit.Dimension == model.DimensionMass:
+		return amount * fromUnit.ToBase * ingredient.UnitWeightG / toUnit.ToBase, true
+	case fromUnit.Dimension == model.DimensionMass && toUnit.Dimension == model.DimensionCount:
+		return amount * fromUnit.ToBase / ingredient.UnitWeightG / toUnit.ToBase, true
+	}
+	return 0, false
+}
+
+func (s *PantryService) validateItem(ctx context.Context, item *model.PantryItem) error {
+	if item.IngredientID == "" {
+		return puberr.NewPubErr("ingredient_id is required")
+	}
+	quantity, ok := numericAmount(item.Quantity)
+	if !ok {
+		return puberr.NewPubErr("quantity must be between 0.001 and 9999999.999")
+	}
+	item.Quantity = quantity
+
+	units, err := loadUnits(ctx, s.unitRepo)
+	if err != nil {
+		return err
+	}
+	unit, err := units.resolve(item.Unit)
+	if err != nil {
+		return err
+	}
+	item.Unit = unit.Code
+
+	if item.ExpiresOn != nil {
+		day := truncateDay(*item.ExpiresOn)
+		item.ExpiresOn = &day
+	}
+	return nil
+}
+
+// getOwned возвращает запас пользователя; чужой запас неотличим от несуществующего
+func (s *PantryService) getOwned(ctx context.Context, userID, id string) (*model.PantryItem, error) {
+	item, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, puberr.ErrNotFound
+		}
+		return nil, err
+	}
+	if item.UserID != userID {
+		return nil, puberr.ErrNotFound
+	}
+	return item, nil
+}
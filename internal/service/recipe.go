@@ -3,32 +3,64 @@ package service
 import (
 	"CookFinder.Backend/internal/model"
 	"CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
 	"CookFinder.Backend/pkg/uuid"
 	"context"
+	"fmt"
+	"math"
 	"time"
+
+	"github.com/jmoiron/sqlx"
 )
 
 type RecipeService struct {
 	recipeRepo     *repo.RecipeRepository
 	recipeIngrRepo *repo.RecipeIngredientRepository
+	ingredientRepo *repo.IngredientRepository
+	unitRepo       *repo.UnitRepository
+	stepRepo       *repo.RecipeStepRepository
+	fileRepo       *repo.FileRepository
+	substRepo      *repo.SubstitutionRepository
+	pantryRepo     *repo.PantryRepository
 }
 
 func NewRecipeService(
 	repo *repo.RecipeRepository,
 	ingrRepo *repo.RecipeIngredientRepository,
+	ingredientRepo *repo.IngredientRepository,
+	unitRepo *repo.UnitRepository,
+	stepRepo *repo.RecipeStepRepository,
+	fileRepo *repo.FileRepository,
+	substRepo *repo.SubstitutionRepository,
+	pantryRepo *repo.PantryRepository,
 ) *RecipeService {
 	return &RecipeService{
 		recipeRepo:     repo,
 		recipeIngrRepo: ingrRepo,
+		ingredientRepo: ingredientRepo,
+		unitRepo:       unitRepo,
+		stepRepo:       stepRepo,
+		fileRepo:       fileRepo,
+		substRepo:      substRepo,
+		pantryRepo:     pantryRepo,
 	}
 }
 
-func (s *RecipeService) CreateWithIngredients(ctx context.Context, recipe *model.Recipe, ingredients []model.RecipeIngredient) error {
+// CreateWithIngredients сохраняет рецепт, ингредиенты и шаги в одной транзакции
+func (s *RecipeService) CreateWithIngredients(ctx context.Context, recipe *model.Recipe, ingredients []model.RecipeIngredient, steps []model.RecipeStep) error {
+	if err := s.prepare(ctx, recipe, ingredients); err != nil {
+		return err
+	}
 	if recipe.ID == "" {
 		recipe.ID = uuid.V7().String()
 	}
 	recipe.CreatedAt = time.Now()
 
+	steps, err := buildSteps(recipe, steps)
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.recipeRepo.BeginTx(ctx)
 	if err != nil {
 		return err
@@ -48,26 +80,220 @@ func (s *RecipeService) CreateWithIngredients(ctx context.Context, recipe *model
 		}
 	}
 
+	if err := s.stepRepo.ReplaceWithTx(ctx, tx, recipe.ID, steps); err != nil {
+		return err
+	}
+
+	if err := s.setLinksWithTx(ctx, tx, recipe); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
+// GetByID возвращает рецепт вместе с пошаговым способом приготовления
 func (s *RecipeService) GetByID(ctx context.Context, id string) (*model.RecipeCategoryIngredients, error) {
-	return s.recipeRepo.GetByID(ctx, id)
+	recipe, err := s.recipeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipe.Steps, err = s.stepRepo.GetByRecipeID(ctx, id); err != nil {
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// GetScaled возвращает рецепт с количествами и пищевой ценностью, пересчитанными на servings порций
+func (s *RecipeService) GetScaled(ctx context.Context, id string, servings int) (*model.RecipeCategoryIngredients, error) {
+	if servings <= 0 {
+		return nil, puberr.NewPubErr("servings must be positive")
+	}
+
+	recipe, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	scaleRecipe(recipe, servings)
+	return recipe, nil
+}
+
+// RenderUnits переводит количества ингредиентов в метрическую или имперскую систему
+func (s *RecipeService) RenderUnits(ctx context.Context, system string, recipes ...*model.RecipeCategoryIngredients) error {
+	if !model.IsValidUnitSystem(system) {
+		return puberr.NewPubErr("units must be metric or imperial")
+	}
+
+	units, err := loadUnits(ctx, s.unitRepo)
+	if err != nil {
+		return err
+	}
+
+	for _, recipe := range recipes {
+		for i := range recipe.Ingredients {
+			ing := &recipe.Ingredients[i]
+			unit, err := units.resolve(ing.Unit)
+			if err != nil {
+				// старые записи с нераспознанной единицей отдаём как есть
+				continue
+			}
+			amount, target := units.render(ing.Amount, unit, system)
+			ing.Amount, ing.Unit = amount, target.Code
+		}
+	}
+	return nil
+}
+
+// prepare проверяет рецепт, приводит единицы к каноническим кодам и, если значения
+// не заданы вручную, рассчитывает пищевую ценность по ингредиентам
+func (s *RecipeService) prepare(ctx context.Context, recipe *model.Recipe, ingredients []model.RecipeIngredient) error {
+	if err := validateRecipe(recipe, ingredients); err != nil {
+		return err
+	}
+
+	var err error
+	if recipe.ImageFileID, recipe.ImageURL, err = resolveImage(ctx, s.fileRepo, recipe.ImageFileID, recipe.ImageURL); err != nil {
+		return err
+	}
+
+	units, err := loadUnits(ctx, s.unitRepo)
+	if err != nil {
+		return err
+	}
+	if err := units.normalize(ingredients); err != nil {
+		return err
+	}
+
+	if recipe.NutritionOverride {
+		recipe.NutritionSkipped = []string{}
+		return nil
+	}
+
+	nutrition, err := s.computeNutrition(ctx, units, ingredients)
+	if err != nil {
+		return err
+	}
+	recipe.Energy = int(math.Round(nutrition.Energy))
+	recipe.Fat = roundTenth(nutrition.Fat)
+	recipe.Protein = roundTenth(nutrition.Protein)
+	recipe.Carbs = roundTenth(nutrition.Carbs)
+	recipe.Fiber = roundTenth(nutrition.Fiber)
+	recipe.Sugar = roundTenth(nutrition.Sugar)
+	recipe.Sodium = math.Round(nutrition.Salt * sodiumMgPerSaltGram)
+	recipe.NutritionSkipped = nutrition.Skipped
+	return nil
+}
+
+func (s *RecipeService) GetAll(ctx context.Context, filter model.RecipeFilter, page model.PageRequest) (model.Page[model.RecipeCategoryIngredients], error) {
+	filter.Tags = uniqueIDs(filter.Tags)
+	for _, allergen := range filter.ExcludeAllergens {
+		if !model.IsValidAllergen(allergen) {
+			return model.Page[model.RecipeCategoryIngredients]{}, puberr.NewPubErr(fmt.Sprintf("unknown allergen %q", allergen))
+		}
+	}
+	return s.recipeRepo.GetAll(ctx, filter, page)
 }
 
-func (s *RecipeService) GetAll(ctx context.Context, search, categoryID string) ([]model.RecipeCategoryIngredients, error) {
-	return s.recipeRepo.GetAll(ctx, search, categoryID)
+// FindByPantry подбирает рецепты по ингредиентам пользователя и дополняет каждый результат списком недостающих ингредиентов.
+// Ингредиент, который можно заменить тем, что есть, считается покрытым и попадает в Substituted;
+// учитываются только замены с контекстом any, см. SubstitutionRepository.GetCoveredBy.
+func (s *RecipeService) FindByPantry(ctx context.Context, search model.PantrySearch) ([]model.RecipeMatch, error) {
+	// Ингредиенты, введённые текстом, сопоставляем с наиболее похожими из справочника
+	for _, name := range search.IngredientNames {
+		suggestions, err := s.ingredientRepo.Suggest(ctx, name, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(suggestions) > 0 {
+			search.IngredientIDs = append(search.IngredientIDs, suggestions[0].ID)
+		}
+	}
+
+	if search.PantryUserID != "" {
+		stored, err := s.pantryRepo.GetIngredientIDs(ctx, search.PantryUserID, truncateDay(time.Now()))
+		if err != nil {
+			return nil, err
+		}
+		search.IngredientIDs = uniqueIDs(append(search.IngredientIDs, stored...))
+	}
+
+	if len(search.IngredientIDs) == 0 {
+		return nil, puberr.NewPubErr("ingredient_ids or ingredients is required")
+	}
+	if search.MinCoverage < 0 || search.MinCoverage > 1 {
+		return nil, puberr.NewPubErr("min_coverage must be between 0 and 1")
+	}
+
+	covered, err := s.substRepo.GetCoveredBy(ctx, search.IngredientIDs)
+	if err != nil {
+		return nil, err
+	}
+	search.SubstitutedIDs = covered
+
+	matches, err := s.recipeRepo.FindByPantry(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	pantry := make(map[string]struct{}, len(search.IngredientIDs))
+	for _, id := range search.IngredientIDs {
+		pantry[id] = struct{}{}
+	}
+	substituted := make(map[string]struct{}, len(covered))
+	for _, id := range covered {
+		substituted[id] = struct{}{}
+	}
+
+	for i := range matches {
+		missing := make([]model.IngredientWithAmount, 0)
+		replaced := make([]model.IngredientWithAmount, 0)
+		for _, ing := range matches[i].Ingredients {
+			if _, ok := pantry[ing.ID]; ok {
+				continue
+			}
+			if _, ok := substituted[ing.ID]; ok {
+				replaced = append(replaced, ing)
+				continue
+			}
+			missing = append(missing, ing)
+		}
+		matches[i].Missing = missing
+		matches[i].Substituted = replaced
+	}
+
+	return matches, nil
 }
 
 func (s *RecipeService) Update(ctx context.Context, recipe *model.Recipe) error {
 	return s.recipeRepo.Update(ctx, recipe)
 }
 
-func (s *RecipeService) Delete(ctx context.Context, id string) error {
+func (s *RecipeService) Delete(ctx context.Context, actor model.Principal, id string) error {
+	if _, err := s.getOwned(ctx, actor, id); err != nil {
+		return err
+	}
+
 	return s.recipeRepo.Delete(ctx, id)
 }
 
-func (s *RecipeService) UpdateWithIngredients(ctx context.Context, recipe *model.Recipe, ingredients []model.RecipeIngredient) error {
+// UpdateWithIngredients заменяет рецепт, его ингредиенты и шаги; изменять рецепт может только автор или админ.
+// Права проверяются до разбора содержимого, чтобы чужой рецепт не раскрывал ошибки валидации.
+func (s *RecipeService) UpdateWithIngredients(ctx context.Context, actor model.Principal, recipe *model.Recipe, ingredients []model.RecipeIngredient, steps []model.RecipeStep) error {
+	existing, err := s.getOwned(ctx, actor, recipe.ID)
+	if err != nil {
+		return err
+	}
+	recipe.AuthorID = existing.Recipe.AuthorID
+	recipe.CreatedAt = existing.Recipe.CreatedAt
+
+	if err := s.prepare(ctx, recipe, ingredients); err != nil {
+		return err
+	}
+	if steps, err = buildSteps(recipe, steps); err != nil {
+		return err
+	}
+
 	tx, err := s.recipeRepo.BeginTx(ctx)
 	if err != nil {
 		return err
@@ -92,5 +318,35 @@ func (s *RecipeService) UpdateWithIngredients(ctx context.Context, recipe *model
 		}
 	}
 
+	// Шаги заменяются целиком вместе с ингредиентами
+	if err := s.stepRepo.ReplaceWithTx(ctx, tx, recipe.ID, steps); err != nil {
+		return err
+	}
+
+	if err := s.setLinksWithTx(ctx, tx, recipe); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
+
+// setLinksWithTx сохраняет категории и теги рецепта
+func (s *RecipeService) setLinksWithTx(ctx context.Context, tx *sqlx.Tx, recipe *model.Recipe) error {
+	if err := s.recipeRepo.SetCategoriesWithTx(ctx, tx, recipe.ID, recipe.CategoryIDs); err != nil {
+		return err
+	}
+	return s.recipeRepo.SetTagsWithTx(ctx, tx, recipe.ID, recipe.TagIDs)
+}
+
+func (s *RecipeService) getOwned(ctx context.Context, actor model.Principal, id string) (*model.RecipeCategoryIngredients, error) {
+	recipe, err := s.recipeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor.UserID == "" || !actor.HasRole(model.RoleAdmin) && recipe.Recipe.AuthorID != actor.UserID {
+		return nil, puberr.ErrNotOwnedResource
+	}
+
+	return recipe, nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxReviewLength = 4000
+
+type ReviewService struct {
+	repo *repo.ReviewRepository
+}
+
+func NewReviewService(repo *repo.ReviewRepository) *ReviewService {
+	return &ReviewService{repo: repo}
+}
+
+// Save создаёт или заменяет отзыв пользователя: у одного пользователя на рецепт не больше одного отзыва
+func (s *ReviewService) Save(ctx context.Context, userID, recipeID string, rating int, body string) (*model.Review, error) {
+	if rating < 1 || rating > 5 {
+		return nil, puberr.NewPubErr("rating must be between 1 and 5")
+	}
+	body = strings.TrimSpace(body)
+	if utf8.RuneCountInString(body) > maxReviewLength {
+		return nil, puberr.NewPubErr("review is too long")
+	}
+
+	now := time.Now()
+	review := &model.Review{
+		RecipeID:  recipeID,
+		UserID:    userID,
+		Rating:    rating,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.Upsert(ctx, review); err != nil {
+		return nil, err
+	}
+
+	// created_at при замене остаётся прежним, поэтому перечитываем сохранённую запись
+	return s.repo.Get(ctx, recipeID, userID)
+}
+
+func (s *ReviewService) GetByRecipeID(ctx context.Context, recipeID string, page model.PageRequest) (model.Page[model.Review], error) {
+	return s.repo.GetByRecipeID(ctx, recipeID, page)
+}
+
+// Delete удаляет отзыв userID; чужие отзывы может удалять только админ
+func (s *ReviewService) Delete(ctx context.Context, actor model.Principal, recipeID, userID string) error {
+	if actor.UserID != userID && !actor.HasRole(model.RoleAdmin) {
+		return puberr.ErrNotOwnedResource
+	}
+
+	if _, err := s.repo.Get(ctx, recipeID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return puberr.ErrNotFound
+		}
+		return err
+	}
+
+	return s.repo.Delete(ctx, recipeID, userID)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// amountSteps — шаг округления пересчитанного количества по коду единицы измерения
+var amountSteps = map[string]float64{
+	"pcs": 0.5,
+	"g":   5,
+	"ml":  5,
+	"kg":  0.05,
+	"l":   0.05,
+}
+
+// defaultAmountStep подходит для ложек, стаканов и прочих единиц
+const defaultAmountStep = 0.25
+
+// smallAmount — граммы и миллилитры меньше этого значения округляются до целых, а не до 5
+const smallAmount = 10
+
+func roundAmount(amount float64, unit string) float64 {
+	step, ok := amountSteps[unit]
+	if !ok {
+		step = defaultAmountStep
+	}
+	if step >= 5 && amount < smallAmount {
+		step = 1
+	}
+
+	rounded := math.Round(amount/step) * step
+	// ненулевое количество не должно пропадать из рецепта после округления
+	if rounded == 0 && amount > 0 {
+		rounded = step
+	}
+	return rounded
+}
+
+// scaleRecipe пересчитывает ингредиенты и пищевую ценность рецепта на servings порций
+func scaleRecipe(recipe *model.RecipeCategoryIngredients, servings int) {
+	base := recipe.Recipe.Servings
+	if base <= 0 {
+		base = 1
+	}
+	if servings == base {
+		return
+	}
+	factor := float64(servings) / float64(base)
+
+	for i := range recipe.Ingredients {
+		ing := &recipe.Ingredients[i]
+		ing.Amount = roundAmount(ing.Amount*factor, ing.Unit)
+	}
+
+	r := &recipe.Recipe
+	r.Energy = int(math.Round(float64(r.Energy) * factor))
+	r.Fat = roundTenth(r.Fat * factor)
+	r.Protein = roundTenth(r.Protein * factor)
+	r.Carbs = roundTenth(r.Carbs * factor)
+	r.Fiber = roundTenth(r.Fiber * factor)
+	r.Sugar = roundTenth(r.Sugar * factor)
+	r.Sodium = math.Round(r.Sodium * factor)
+	recipe.Recipe.Servings = servings
+}
+
+// validateRecipe подставляет одну порцию по умолчанию, проверяет количества ингредиентов
+// и нормализует ручные диетические метки
+func validateRecipe(recipe *model.Recipe, ingredients []model.RecipeIngredient) error {
+	if recipe.Servings == 0 {
+		recipe.Servings = 1
+	}
+	if recipe.Servings < 0 {
+		return puberr.NewPubErr("servings must be positive")
+	}
+
+	for _, ing := range ingredients {
+		if ing.Amount < 0 {
+			return puberr.NewPubErr("ingredient amount must not be negative")
+		}
+	}
+
+	tags := make([]string, 0, len(recipe.DietTags))
+	for _, tag := range recipe.DietTags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || slices.Contains(tags, tag) {
+			continue
+		}
+		if model.IsDerivedDiet(tag) {
+			return puberr.NewPubErr(fmt.Sprintf("diet tag %q is derived from ingredients and cannot be set manually", tag))
+		}
+		tags = append(tags, tag)
+	}
+	recipe.DietTags = tags
+
+	// Старые клиенты передают только category_id, новые — список, первая категория становится основной
+	if len(recipe.CategoryIDs) == 0 && recipe.CategoryID != "" {
+		recipe.CategoryIDs = []string{recipe.CategoryID}
+	}
+	recipe.CategoryIDs = uniqueIDs(recipe.CategoryIDs)
+	if len(recipe.CategoryIDs) == 0 {
+		return puberr.NewPubErr("at least one category is required")
+	}
+	recipe.CategoryID = recipe.CategoryIDs[0]
+	recipe.TagIDs = uniqueIDs(recipe.TagIDs)
+	return nil
+}
+
+// uniqueIDs убирает пустые и повторяющиеся ID, сохраняя порядок
+func uniqueIDs(ids []string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || slices.Contains(result, id) {
+			continue
+		}
+		result = append(result, id)
+	}
+	return result
+}
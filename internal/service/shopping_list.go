@@ -0,0 +1,375 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/uuid"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	maxShoppingListRecipes  = 50
+	defaultShoppingListName = "Shopping list"
+	shareTokenBytes         = 24
+)
+
+type ShoppingListService struct {
+	repo           *repo.ShoppingListRepository
+	recipeRepo     *repo.RecipeRepository
+	recipeIngrRepo *repo.RecipeIngredientRepository
+	ingredientRepo *repo.IngredientRepository
+	unitRepo       *repo.UnitRepository
+}
+
+func NewShoppingListService(
+	repo *repo.ShoppingListRepository,
+	recipeRepo *repo.RecipeRepository,
+	recipeIngrRepo *repo.RecipeIngredientRepository,
+	ingredientRepo *repo.IngredientRepository,
+	unitRepo *repo.UnitRepository,
+) *ShoppingListService {
+	return &ShoppingListService{
+		repo:           repo,
+		recipeRepo:     recipeRepo,
+		recipeIngrRepo: recipeIngrRepo,
+		ingredientRepo: ingredientRepo,
+		unitRepo:       unitRepo,
+	}
+}
+
+// Create составляет список покупок из рецептов: количества одного ингредиента пересчитываются
+// на нужное число порций и суммируются. Servings 0 — порции, на которые написан рецепт.
+func (s *ShoppingListService) Create(ctx context.Context, userID, name string, recipes []model.ShoppingListRecipe) (*model.ShoppingList, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = defaultShoppingListName
+	}
+
+	recipes, factors, err := s.resolveRecipes(ctx, recipes)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.consolidateRecipes(ctx, factors)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &model.ShoppingList{
+		ID:        uuid.V7().String(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: time.Now(),
+		Recipes:   recipes,
+		Items:     items,
+	}
+	if err := s.repo.Create(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// resolveRecipes проверяет рецепты, подставляет порции по умолчанию и объединяет повторы.
+// Вместе с рецептами возвращает множитель количеств для каждого из них.
+func (s *ShoppingListService) resolveRecipes(ctx context.Context, input []model.ShoppingListRecipe) ([]model.ShoppingListRecipe, map[string]float64, error) {
+	ids := make([]string, 0, len(input))
+	index := make(map[string]int, len(input))
+	for _, r := range input {
+		if r.Servings < 0 {
+			return nil, nil, puberr.NewPubErr("servings must be positive")
+		}
+		if _, ok := index[r.RecipeID]; !ok {
+			index[r.RecipeID] = len(ids)
+			ids = append(ids, r.RecipeID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, nil, puberr.NewPubErr("at least one recipe is required")
+	}
+	if len(ids) > maxShoppingListRecipes {
+		return nil, nil, puberr.NewPubErr("too many recipes in one shopping list")
+	}
+
+	found, err := s.recipeRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	recipes := make([]model.ShoppingListRecipe, len(ids))
+	bases := make([]int, len(ids))
+	for i, recipe := range found {
+		if recipe == nil {
+			return nil, nil, puberr.NewPubErr("recipe not found").SetHTTPCode(http.StatusNotFound)
+		}
+		recipes[i] = model.ShoppingListRecipe{RecipeID: ids[i], Title: recipe.Recipe.Title}
+		bases[i] = max(recipe.Recipe.Servings, 1)
+	}
+
+	// повтор рецепта — это ещё порции того же блюда; 0 заменяется порциями рецепта до сложения
+	for _, r := range input {
+		i := index[r.RecipeID]
+		if r.Servings == 0 {
+			r.Servings = bases[i]
+		}
+		recipes[i].Servings += r.Servings
+	}
+
+	factors := make(map[string]float64, len(recipes))
+	for i, r := range recipes {
+		factors[r.RecipeID] = float64(r.Servings) / float64(bases[i])
+	}
+	return recipes, factors, nil
+}
+
+// consolidateRecipes загружает ингредиенты рецептов и сводит их в позиции списка;
+// factors — множитель количеств по ID рецепта
+func (s *ShoppingListService) consolidateRecipes(ctx context.Context, factors map[string]float64) ([]model.ShoppingListItem, error) {
+	ids := make([]string, 0, len(factors))
+	for id := range factors {
+		ids = append(ids, id)
+	}
+
+	lines, err := s.recipeIngrRepo.GetByRecipeIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lines {
+		lines[i].Amount *= factors[lines[i].RecipeID]
+	}
+
+	ingredientIDs := make([]string, 0, len(lines))
+	for _, line := range lines {
+		ingredientIDs = append(ingredientIDs, line.IngredientID)
+	}
+	ingredients, err := s.ingredientRepo.GetByIDs(ctx, uniqueIDs(ingredientIDs))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]model.Ingredient, len(ingredients))
+	for _, ing := range ingredients {
+		byID[ing.ID] = ing
+	}
+
+	units, err := loadUnits(ctx, s.unitRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	items := consolidate(units, lines, byID)
+	for i := range items {
+		items[i].ID = uuid.V7().String()
+	}
+	return items, nil
+}
+
+// shoppingAmounts — накопленные количества одного ингредиента
+type shoppingAmounts struct {
+	base map[string]float64 // размерность -> количество в g, ml или pcs
+	raw  map[string]float64 // единица не из справочника -> количество
+}
+
+// consolidate суммирует количества одного ингредиента из разных рецептов в базовых единицах размерности.
+// Объём и штуки добавляются к массе, если ингредиент встречается и в граммах, а плотность или масса штуки известны;
+// иначе остаются отдельными позициями. Единицы не из справочника суммируются только между собой.
+func consolidate(units *unitRegistry, lines []model.RecipeIngredient, ingredients map[string]model.Ingredient) []model.ShoppingListItem {
+	totals := make(map[string]*shoppingAmounts)
+	for _, line := range lines {
+		if _, ok := ingredients[line.IngredientID]; !ok {
+			continue
+		}
+		total, ok := totals[line.IngredientID]
+		if !ok {
+			total = &shoppingAmounts{base: map[string]float64{}, raw: map[string]float64{}}
+			totals[line.IngredientID] = total
+		}
+
+		if unit, err := units.resolve(line.Unit); err == nil {
+			total.base[unit.Dimension] += line.Amount * unit.ToBase
+		} else {
+			total.raw[strings.TrimSpace(line.Unit)] += line.Amount
+		}
+	}
+
+	items := make([]model.ShoppingListItem, 0, len(totals))
+	for id, total := range totals {
+		ingredient := ingredients[id]
+		mergeIntoMass(total.base, ingredient)
+
+		for dimension, amount := range total.base {
+			amount, unit, ok := units.fit(amount, dimension)
+			if !ok {
+				continue
+			}
+			items = append(items, shoppingItem(ingredient, amount, unit.Code))
+		}
+		for unit, amount := range total.raw {
+			items = append(items, shoppingItem(ingredient, roundConverted(amount), unit))
+		}
+	}
+
+	sortShoppingItems(items)
+	return items
+}
+
+// mergeIntoMass переводит объём и штуки в граммы, если ингредиент уже нужен в граммах
+func mergeIntoMass(base map[string]float64, ingredient model.Ingredient) {
+	if _, ok := base[model.DimensionMass]; !ok {
+		return
+	}
+	if volume, ok := base[model.DimensionVolume]; ok && ingredient.DensityGPerMl > 0 {
+		base[model.DimensionMass] += volume * ingredient.DensityGPerMl
+		delete(base, model.DimensionVolume)
+	}
+	if count, ok := base[model.DimensionCount]; ok && ingredient.UnitWeightG > 0 {
+		base[model.DimensionMass] += count * ingredient.UnitWeightG
+		delete(base, model.DimensionCount)
+	}
+}
+
+func shoppingItem(ingredient model.Ingredient, amount float64, unit string) model.ShoppingListItem {
+	return model.ShoppingListItem{
+		IngredientID: ingredient.ID,
+		Name:         ingredient.Name,
+		Aisle:        ingredient.Aisle,
+		Amount:       amount,
+		Unit:         unit,
+	}
+}
+
+// sortShoppingItems упорядочивает позиции по отделам в порядке обхода магазина, затем по названию
+func sortShoppingItems(items []model.ShoppingListItem) {
+	aisle := func(name string) int {
+		if i := slices.Index(model.Aisles, name); i >= 0 {
+			return i
+		}
+		return len(model.Aisles)
+	}
+	slices.SortFunc(items, func(a, b model.ShoppingListItem) int {
+		return cmp.Or(
+			cmp.Compare(aisle(a.Aisle), aisle(b.Aisle)),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Unit, b.Unit),
+		)
+	})
+}
+
+func (s *ShoppingListService) GetAll(ctx context.Context, userID string) ([]model.ShoppingList, error) {
+	return s.repo.GetByUserID(ctx, userID)
+}
+
+func (s *ShoppingListService) GetByID(ctx context.Context, userID, id string) (*model.ShoppingList, error) {
+	list, err := s.getOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return list, s.load(ctx, list)
+}
+
+// GetShared открывает список по ссылке только для чтения; вход не требуется
+func (s *ShoppingListService) GetShared(ctx context.Context, token string) (*model.ShoppingList, error) {
+	if token == "" {
+		return nil, puberr.ErrNotFound
+	}
+	list, err := s.repo.GetByShareToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, puberr.ErrNotFound
+		}
+		return nil, err
+	}
+	return list, s.load(ctx, list)
+}
+
+func (s *ShoppingListService) load(ctx context.Context, list *model.ShoppingList) error {
+	recipes, err := s.repo.GetRecipes(ctx, list.ID)
+	if err != nil {
+		return err
+	}
+	items, err := s.repo.GetItems(ctx, list.ID)
+	if err != nil {
+		return err
+	}
+
+	sortShoppingItems(items)
+	list.Recipes = recipes
+	list.Items = items
+	return nil
+}
+
+// SetChecked сохраняет отметку о покупке позиции
+func (s *ShoppingListService) SetChecked(ctx context.Context, userID, id, itemID string, checked bool) error {
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+
+	found, err := s.repo.SetChecked(ctx, id, itemID, checked)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return puberr.ErrNotFound
+	}
+	return nil
+}
+
+// Share выдаёт токен ссылки только для чтения; повторный вызов возвращает уже выданный токен
+func (s *ShoppingListService) Share(ctx context.Context, userID, id string) (string, error) {
+	list, err := s.getOwned(ctx, userID, id)
+	if err != nil {
+		return "", err
+	}
+	if list.ShareToken != "" {
+		return list.ShareToken, nil
+	}
+
+	raw := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	if err := s.repo.SetShareToken(ctx, id, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Unshare отзывает ссылку: старый токен перестаёт работать
+func (s *ShoppingListService) Unshare(ctx context.Context, userID, id string) error {
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.SetShareToken(ctx, id, "")
+}
+
+func (s *ShoppingListService) Delete(ctx context.Context, userID, id string) error {
+	if _, err := s.getOwned(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// getOwned скрывает чужие списки так же, как несуществующие
+func (s *ShoppingListService) getOwned(ctx context.Context, userID, id string) (*model.ShoppingList, error) {
+	list, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, puberr.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if list.UserID != userID {
+		return nil, puberr.ErrNotFound
+	}
+	return list, nil
+}
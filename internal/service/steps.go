@@ -0,0 +1,75 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/pkg/puberr"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// stepNumber — нумерация в начале строки: «1.», «2)», «Шаг 3:»
+	stepNumber = regexp.MustCompile(`(?i)^\s*(шаг\s*)?\d+\s*[.):]\s*`)
+	// inlineStep — начало следующего шага в однострочном тексте «1. … 2. …»
+	inlineStep = regexp.MustCompile(`\s+\d+[.)]\s`)
+	firstStep  = regexp.MustCompile(`^\s*1[.)]\s`)
+)
+
+// buildSteps согласует шаги и method: если шаги не переданы, они получаются разбиением method,
+// иначе method собирается из шагов, чтобы по нему продолжал работать поиск и старые клиенты.
+func buildSteps(recipe *model.Recipe, steps []model.RecipeStep) ([]model.RecipeStep, error) {
+	if len(steps) == 0 {
+		steps = splitMethod(recipe.Method)
+	}
+
+	lines := make([]string, len(steps))
+	for i := range steps {
+		step := &steps[i]
+		step.Text = strings.TrimSpace(step.Text)
+		if step.Text == "" {
+			return nil, puberr.NewPubErr(fmt.Sprintf("step %d has no text", i+1))
+		}
+		if step.DurationSec < 0 {
+			return nil, puberr.NewPubErr(fmt.Sprintf("step %d has negative duration", i+1))
+		}
+		step.RecipeID = recipe.ID
+		step.Ordinal = i + 1
+		lines[i] = fmt.Sprintf("%d. %s", step.Ordinal, step.Text)
+	}
+
+	recipe.Method = strings.Join(lines, "\n")
+	return steps, nil
+}
+
+// splitMethod делит текст способа приготовления на шаги по строкам, а однострочный текст
+// вида «1. … 2. …» — по номерам шагов, идущим подряд
+func splitMethod(method string) []model.RecipeStep {
+	var parts []string
+	if !strings.Contains(method, "\n") && firstStep.MatchString(method) {
+		prev, next := 0, 2
+		for _, loc := range inlineStep.FindAllStringIndex(method, -1) {
+			// «нагреть до 180. Затем» не должно становиться новым шагом
+			number := strings.TrimRight(strings.TrimSpace(method[loc[0]:loc[1]]), ".)")
+			if number != strconv.Itoa(next) {
+				continue
+			}
+			parts = append(parts, method[prev:loc[0]])
+			prev = loc[0]
+			next++
+		}
+		parts = append(parts, method[prev:])
+	} else {
+		parts = strings.Split(method, "\n")
+	}
+
+	steps := make([]model.RecipeStep, 0, len(parts))
+	for _, part := range parts {
+		text := strings.TrimSpace(stepNumber.ReplaceAllString(strings.TrimSpace(part), ""))
+		if text != "" {
+			steps = append(steps, model.RecipeStep{Text: text})
+		}
+	}
+	return steps
+}
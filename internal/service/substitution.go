@@ -0,0 +1,204 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/uuid"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type SubstitutionService struct {
+	repo       *repo.SubstitutionRepository
+	recipeRepo *repo.RecipeRepository
+}
+
+func NewSubstitutionService(repo *repo.SubstitutionRepository, recipeRepo *repo.RecipeRepository) *SubstitutionService {
+	return &SubstitutionService{
+		repo:       repo,
+		recipeRepo: recipeRepo,
+	}
+}
+
+func (s *SubstitutionService) Create(ctx context.Context, sub *model.IngredientSubstitution) (*model.IngredientSubstitution, error) {
+	if err := validateSubstitution(sub); err != nil {
+		return nil, err
+	}
+
+	sub.ID = uuid.V7().String()
+	sub.CreatedAt = time.Now()
+	if err := s.repo.Create(ctx, sub); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, sub.ID)
+}
+
+func (s *SubstitutionService) Update(ctx context.Context, sub *model.IngredientSubstitution) (*model.IngredientSubstitution, error) {
+	if _, err := s.repo.GetByID(ctx, sub.ID); err != nil {
+		return nil, err
+	}
+	if err := validateSubstitution(sub); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, sub); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, sub.ID)
+}
+
+func (s *SubstitutionService) GetByID(ctx context.Context, id string) (*model.IngredientSubstitution, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *SubstitutionService) GetAll(ctx context.Context, ingredientID string, page model.PageRequest) (model.Page[model.IngredientSubstitution], error) {
+	return s.repo.GetAll(ctx, ingredientID, page)
+}
+
+func (s *SubstitutionService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// ForRecipe подбирает замены для ингредиентов рецепта, которых нет в pantry.
+// Без pantry замены возвращаются для всех ингредиентов. Варианты, для которых всё есть, идут первыми.
+func (s *SubstitutionService) ForRecipe(ctx context.Context, recipeID string, pantry []string) ([]model.RecipeSubstitutions, error) {
+	recipe, err := s.recipeRepo.GetByID(ctx, recipeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, puberr.ErrNotFound
+		}
+		return nil, err
+	}
+
+	inPantry := make(map[string]bool, len(pantry))
+	for _, id := range pantry {
+		inPantry[id] = true
+	}
+
+	missing := make([]model.IngredientWithAmount, 0, len(recipe.Ingredients))
+	ids := make([]string, 0, len(recipe.Ingredients))
+	for _, ing := range recipe.Ingredients {
+		if !inPantry[ing.ID] {
+			missing = append(missing, ing)
+			ids = append(ids, ing.ID)
+		}
+	}
+
+	subs, err := s.repo.GetForIngredients(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.RecipeSubstitutions, 0, len(missing))
+	for _, ing := range missing {
+		options := make([]model.SubstitutionOption, 0)
+		for _, sub := range subs {
+			parts, ok := substitutesFor(sub, ing.ID)
+			if !ok {
+				continue
+			}
+			options = append(options, newSubstitutionOption(sub, parts, ing, inPantry))
+		}
+		// сначала то, для чего всё есть; sort стабильный, поэтому внутри групп порядок из репозитория
+		slices.SortStableFunc(options, func(a, b model.SubstitutionOption) int {
+			switch {
+			case a.Available == b.Available:
+				return 0
+			case a.Available:
+				return -1
+			default:
+				return 1
+			}
+		})
+		result = append(result, model.RecipeSubstitutions{Ingredient: ing, Options: options})
+	}
+	return result, nil
+}
+
+// substitutesFor возвращает заменители ingredientID по замене sub; для двусторонней замены
+// в обратную сторону заменителем становится исходный ингредиент с обратной пропорцией
+func substitutesFor(sub model.IngredientSubstitution, ingredientID string) ([]model.SubstitutionPart, bool) {
+	if sub.IngredientID == ingredientID {
+		return sub.Parts, true
+	}
+	if sub.Bidirectional && len(sub.Parts) == 1 && sub.Parts[0].IngredientID == ingredientID {
+		return []model.SubstitutionPart{{
+			SubstitutionID: sub.ID,
+			IngredientID:   sub.IngredientID,
+			Name:           sub.IngredientName,
+			Ratio:          1 / sub.Parts[0].Ratio,
+		}}, true
+	}
+	return nil, false
+}
+
+func newSubstitutionOption(sub model.IngredientSubstitution, parts []model.SubstitutionPart, ing model.IngredientWithAmount, inPantry map[string]bool) model.SubstitutionOption {
+	option := model.SubstitutionOption{
+		SubstitutionID: sub.ID,
+		Context:        sub.Context,
+		Notes:          sub.Notes,
+		Parts:          make([]model.SubstitutionAmount, 0, len(parts)),
+		Available:      true,
+	}
+	for _, part := range parts {
+		option.Parts = append(option.Parts, model.SubstitutionAmount{
+			IngredientID: part.IngredientID,
+			Name:         part.Name,
+			Amount:       roundConverted(ing.Amount * part.Ratio),
+			Unit:         ing.Unit,
+			InPantry:     inPantry[part.IngredientID],
+		})
+		option.Available = option.Available && inPantry[part.IngredientID]
+	}
+	return option
+}
+
+func validateSubstitution(sub *model.IngredientSubstitution) error {
+	if sub.IngredientID == "" {
+		return puberr.NewPubErr("ingredient_id is required")
+	}
+
+	sub.Context = strings.TrimSpace(sub.Context)
+	if sub.Context == "" {
+		sub.Context = model.SubstitutionAny
+	}
+	if !model.IsValidSubstitutionContext(sub.Context) {
+		return puberr.NewPubErr(fmt.Sprintf("unknown substitution context %q", sub.Context))
+	}
+	sub.Notes = strings.TrimSpace(sub.Notes)
+
+	if len(sub.Parts) == 0 {
+		return puberr.NewPubErr("at least one substitute is required")
+	}
+	seen := make(map[string]bool, len(sub.Parts))
+	for i := range sub.Parts {
+		part := &sub.Parts[i]
+		if part.IngredientID == "" {
+			return puberr.NewPubErr("substitute ingredient_id is required")
+		}
+		if part.IngredientID == sub.IngredientID {
+			return puberr.NewPubErr("ingredient cannot substitute itself")
+		}
+		if seen[part.IngredientID] {
+			return puberr.NewPubErr("substitutes must not repeat")
+		}
+		seen[part.IngredientID] = true
+		// ratio хранится в NUMERIC(10, 3), как количество в запасах
+		ratio, ok := numericAmount(part.Ratio)
+		if !ok {
+			return puberr.NewPubErr("ratio must be between 0.001 and 9999999.999")
+		}
+		part.Ratio = ratio
+	}
+
+	if sub.Bidirectional && len(sub.Parts) != 1 {
+		return puberr.NewPubErr("only a single-ingredient substitution can be bidirectional")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
+	"CookFinder.Backend/pkg/uuid"
+	"context"
+	"strings"
+)
+
+type TagService struct {
+	repo *repo.TagRepository
+}
+
+func NewTagService(repo *repo.TagRepository) *TagService {
+	return &TagService{repo: repo}
+}
+
+func (s *TagService) Create(ctx context.Context, tag *model.Tag) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+	if tag.ID == "" {
+		tag.ID = uuid.V7().String()
+	}
+	return s.repo.Create(ctx, tag)
+}
+
+func (s *TagService) GetAll(ctx context.Context, kind string, page model.PageRequest) (model.Page[model.Tag], error) {
+	if kind != "" && !model.IsValidTagKind(kind) {
+		return model.Page[model.Tag]{}, puberr.NewPubErr("unknown tag kind")
+	}
+	return s.repo.GetAll(ctx, kind, page)
+}
+
+func (s *TagService) GetByID(ctx context.Context, id string) (*model.Tag, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *TagService) Update(ctx context.Context, tag *model.Tag) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, tag)
+}
+
+func (s *TagService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// validateTag обрезает пробелы в названии и подставляет вид по умолчанию
+func validateTag(tag *model.Tag) error {
+	tag.Name = strings.TrimSpace(tag.Name)
+	if tag.Name == "" {
+		return puberr.NewPubErr("tag name is required")
+	}
+	if tag.Kind == "" {
+		tag.Kind = model.TagOther
+	}
+	if !model.IsValidTagKind(tag.Kind) {
+		return puberr.NewPubErr("tag kind must be cuisine, meal_type, occasion or other")
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"CookFinder.Backend/internal/model"
+	"CookFinder.Backend/internal/repo"
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+type UnitService struct {
+	repo           *repo.UnitRepository
+	ingredientRepo *repo.IngredientRepository
+}
+
+func NewUnitService(repo *repo.UnitRepository, ingredientRepo *repo.IngredientRepository) *UnitService {
+	return &UnitService{
+		repo:           repo,
+		ingredientRepo: ingredientRepo,
+	}
+}
+
+func (s *UnitService) GetAll(ctx context.Context) ([]model.Unit, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// Convert переводит amount из одной единицы в другую. Для пересчёта между массой и объёмом
+// нужен ingredientID с известной плотностью.
+func (s *UnitService) Convert(ctx context.Context, amount float64, from, to, ingredientID string) (float64, *model.Unit, error) {
+	units, err := loadUnits(ctx, s.repo)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	fromUnit, err := units.resolve(from)
+	if err != nil {
+		return 0, nil, err
+	}
+	toUnit, err := units.resolve(to)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var density float64
+	if ingredientID != "" {
+		ingredient, err := s.ingredientRepo.GetByID(ctx, ingredientID)
+		if err != nil {
+			return 0, nil, puberr.ErrResourceNotFound.SetCause(err)
+		}
+		density = ingredient.DensityGPerMl
+	}
+
+	converted, err := convertAmount(amount, fromUnit, toUnit, density)
+	if err != nil {
+		return 0, nil, err
+	}
+	return roundConverted(converted), &toUnit, nil
+}
+
+// unitRegistry — справочник единиц с поиском по коду и синонимам
+type unitRegistry struct {
+	byName map[string]model.Unit
+	units  []model.Unit
+}
+
+func loadUnits(ctx context.Context, repo *repo.UnitRepository) (*unitRegistry, error) {
+	units, err := repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newUnitRegistry(units), nil
+}
+
+func newUnitRegistry(units []model.Unit) *unitRegistry {
+	registry := &unitRegistry{
+		byName: make(map[string]model.Unit, len(units)*4),
+		units:  units,
+	}
+	for _, u := range units {
+		registry.byName[u.Code] = u
+		for _, alias := range u.Aliases {
+			registry.byName[alias] = u
+		}
+	}
+	// от крупных единиц к мелким — так render выбирает самую крупную подходящую
+	sort.Slice(registry.units, func(i, j int) bool { return registry.units[i].ToBase > registry.units[j].ToBase })
+	return registry
+}
+
+func (r *unitRegistry) resolve(name string) (model.Unit, error) {
+	unit, ok := r.byName[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return model.Unit{}, puberr.NewPubErr(fmt.Sprintf("unknown unit %q", name))
+	}
+	return unit, nil
+}
+
+// normalize проверяет единицы ингредиентов и заменяет синонимы каноническими кодами
+func (r *unitRegistry) normalize(ingredients []model.RecipeIngredient) error {
+	for i := range ingredients {
+		unit, err := r.resolve(ingredients[i].Unit)
+		if err != nil {
+			return err
+		}
+		ingredients[i].Unit = unit.Code
+	}
+	return nil
+}
+
+// render переводит количество в единицы нужной системы, если исходная единица к ней не относится
+func (r *unitRegistry) render(amount float64, unit model.Unit, system string) (float64, model.Unit) {
+	if unit.System == system || unit.System == model.SystemAny {
+		return amount, unit
+	}
+
+	base := amount * unit.ToBase
+	var target *model.Unit
+	for i := range r.units {
+		candidate := &r.units[i]
+		if candidate.Dimension != unit.Dimension || candidate.System != system {
+			continue
+		}
+		target = candidate
+		if base/candidate.ToBase >= 1 {
+			break
+		}
+	}
+	if target == nil {
+		return amount, unit
+	}
+	return roundConverted(base / target.ToBase), *target
+}
+
+// fit записывает количество в базовых единицах (g, ml, pcs) самой крупной метрической единицей,
+// в которой оно не меньше 1: 1500 g — это 1.5 kg
+func (r *unitRegistry) fit(base float64, dimension string) (float64, model.Unit, bool) {
+	var target *model.Unit
+	for i := range r.units {
+		candidate := &r.units[i]
+		if candidate.Dimension != dimension || candidate.System == model.SystemImperial {
+			continue
+		}
+		target = candidate
+		if base/candidate.ToBase >= 1 {
+			break
+		}
+	}
+	if target == nil {
+		return base, model.Unit{}, false
+	}
+	return roundConverted(base / target.ToBase), *target, true
+}
+
+func convertAmount(amount float64, from, to model.Unit, density float64) (float64, error) {
+	base := amount * from.ToBase
+	switch {
+	case from.Dimension == to.Dimension:
+	case from.Dimension == model.DimensionVolume && to.Dimension == model.DimensionMass && density > 0:
+		base *= density
+	case from.Dimension == model.DimensionMass && to.Dimension == model.DimensionVolume && density > 0:
+		base /= density
+	default:
+		return 0, puberr.NewPubErr(fmt.Sprintf("cannot convert %s to %s", from.Code, to.Code))
+	}
+	return base / to.ToBase, nil
+}
+
+// roundConverted оставляет два знака у малых величин и округляет большие
+func roundConverted(amount float64) float64 {
+	switch {
+	case amount < 10:
+		return math.Round(amount*100) / 100
+	case amount < 100:
+		return math.Round(amount*10) / 10
+	default:
+		return math.Round(amount)
+	}
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalDir — каталог локального хранилища, он же раздаётся по маршруту /static
+const LocalDir = "./uploads"
+
+// LocalStorage хранит объекты на диске; для разработки без облака
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStorage(dir, baseURL string) (*LocalStorage, error) {
+	if baseURL == "" {
+		baseURL = "/static"
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+func (it *LocalStorage) Put(_ context.Context, key string, reader io.Reader, _ int64, _ string) (string, error) {
+	name, err := it.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return "", err
+	}
+
+	// пишем во временный файл, чтобы по /static не отдавался недописанный объект
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, reader); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return "", err
+	}
+	return it.URL(key), nil
+}
+
+func (it *LocalStorage) Get(_ context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	name, err := it.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, nil, fsError(err)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, newFileObjectInfo(key, stat), nil
+}
+
+func (it *LocalStorage) Delete(_ context.Context, key string) error {
+	name, err := it.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (it *LocalStorage) Stat(_ context.Context, key string) (*ObjectInfo, error) {
+	name, err := it.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := os.Stat(name)
+	if err != nil {
+		return nil, fsError(err)
+	}
+	return newFileObjectInfo(key, stat), nil
+}
+
+// PresignedURL для GET отдаёт публичную ссылку: /static не требует авторизации.
+// Прямая загрузка на диск в обход API не поддерживается.
+func (it *LocalStorage) PresignedURL(_ context.Context, method, key string, _ time.Duration) (string, error) {
+	if method != http.MethodGet {
+		return "", ErrPresignNotAllowed
+	}
+	return it.URL(key), nil
+}
+
+func (it *LocalStorage) URL(key string) string {
+	return it.baseURL + "/" + key
+}
+
+func (it *LocalStorage) List(_ context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	err := filepath.WalkDir(it.dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(it.dir, name)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		// недописанные временные файлы Put
+		if !strings.HasPrefix(key, prefix) || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		stat, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, *newFileObjectInfo(key, stat))
+		return nil
+	})
+	return objects, err
+}
+
+// path не даёт ключу выйти за пределы каталога хранилища
+func (it *LocalStorage) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(it.dir, filepath.FromSlash(key)), nil
+}
+
+func newFileObjectInfo(key string, stat os.FileInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  mime.TypeByExtension(path.Ext(key)),
+		LastModified: stat.ModTime(),
+	}
+}
+
+func fsError(err error) error {
+	if os.IsNotExist(err) {
+		return ErrObjectNotFound.SetCause(err)
+	}
+	return err
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStorage держит объекты в памяти процесса; для тестов и запуска без диска и облака
+type MemoryStorage struct {
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+	baseURL string
+}
+
+type memoryObject struct {
+	data []byte
+	info ObjectInfo
+}
+
+func NewMemoryStorage(baseURL string) *MemoryStorage {
+	if baseURL == "" {
+		baseURL = "memory://"
+	}
+	return &MemoryStorage{
+		objects: make(map[string]memoryObject),
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (it *MemoryStorage) Put(_ context.Context, key string, reader io.Reader, _ int64, contentType string) (string, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.objects[key] = memoryObject{
+		data: data,
+		info: ObjectInfo{
+			Key:          key,
+			Size:         int64(len(data)),
+			ContentType:  contentType,
+			LastModified: time.Now(),
+		},
+	}
+	return it.URL(key), nil
+}
+
+func (it *MemoryStorage) Get(_ context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	it.mu.RLock()
+	defer it.mu.RUnlock()
+
+	object, ok := it.objects[key]
+	if !ok {
+		return nil, nil, ErrObjectNotFound
+	}
+	info := object.info
+	return io.NopCloser(bytes.NewReader(object.data)), &info, nil
+}
+
+func (it *MemoryStorage) Delete(_ context.Context, key string) error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	delete(it.objects, key)
+	return nil
+}
+
+func (it *MemoryStorage) Stat(_ context.Context, key string) (*ObjectInfo, error) {
+	it.mu.RLock()
+	defer it.mu.RUnlock()
+
+	object, ok := it.objects[key]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+	info := object.info
+	return &info, nil
+}
+
+// PresignedURL для GET отдаёт URL объекта; загружать в память в обход API нельзя
+func (it *MemoryStorage) PresignedURL(_ context.Context, method, key string, _ time.Duration) (string, error) {
+	if method != http.MethodGet {
+		return "", ErrPresignNotAllowed
+	}
+	return it.URL(key), nil
+}
+
+func (it *MemoryStorage) List(_ context.Context, prefix string) ([]ObjectInfo, error) {
+	it.mu.RLock()
+	defer it.mu.RUnlock()
+
+	var objects []ObjectInfo
+	for key, object := range it.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, object.info)
+		}
+	}
+	return objects, nil
+}
+
+func (it *MemoryStorage) URL(key string) string {
+	return it.baseURL + "/" + key
+}
@@ -2,20 +2,24 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"mime/multipart"
-	"path/filepath"
+	"io"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 type S3Storage struct {
 	Client     *s3.Client
 	Bucket     string
 	Uploader   *manager.Uploader
+	Presigner  *s3.PresignClient
 	BaseURL    string
 	FolderPath string
 }
@@ -25,33 +29,156 @@ func NewS3Storage(client *s3.Client, bucket, baseURL, folder string) *S3Storage
 		Client:     client,
 		Bucket:     bucket,
 		Uploader:   manager.NewUploader(client),
+		Presigner:  s3.NewPresignClient(client),
 		BaseURL:    baseURL, // например, https://s3.amazonaws.com/your-bucket
 		FolderPath: folder,
 	}
 }
 
-func (s *S3Storage) UploadFile(file multipart.File, filename string) (string, error) {
-	key := filepath.Join(s.FolderPath, fmt.Sprintf("%d_%s", time.Now().UnixNano(), filename))
+// NewS3StorageFromConfig подходит и для MinIO: адрес задаётся в Endpoint, бакет — в пути
+func NewS3StorageFromConfig(cfg Config) *S3Storage {
+	client := s3.New(s3.Options{
+		Region: cfg.Region,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: cfg.AccessKey, SecretAccessKey: cfg.SecretKey}, nil
+		}),
+		BaseEndpoint: aws.String(cfg.Endpoint),
+		UsePathStyle: true,
+	})
 
-	_, err := s.Uploader.Upload(context.TODO(), &s3.PutObjectInput{
-		Bucket: aws.String(s.Bucket),
-		Key:    aws.String(key),
-		Body:   file,
-		ACL:    "public-read",
+	baseURL := cfg.PublicURL
+	if baseURL == "" {
+		baseURL = strings.TrimSuffix(cfg.Endpoint, "/") + "/" + cfg.Bucket
+	}
+	return NewS3Storage(client, cfg.Bucket, baseURL, "")
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) (string, error) {
+	_, err := s.Uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.Bucket),
+		Key:         aws.String(s.objectKey(key)),
+		Body:        reader,
+		ContentType: aws.String(contentType),
+		ACL:         "public-read",
 	})
 	if err != nil {
 		return "", err
 	}
+	return s.URL(key), nil
+}
 
-	url := fmt.Sprintf("%s/%s", s.BaseURL, key)
-	return url, nil
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	out, err := s.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if err != nil {
+		return nil, nil, s3Error(err)
+	}
+
+	return out.Body, &ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(out.ContentLength),
+		ContentType:  aws.ToString(out.ContentType),
+		LastModified: aws.ToTime(out.LastModified),
+	}, nil
 }
 
-func (s *S3Storage) DeleteFile(path string) error {
-	key := filepath.Base(path) // может потребоваться кастомная логика
-	_, err := s.Client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	_, err := s.Client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(s.Bucket),
-		Key:    aws.String(filepath.Join(s.FolderPath, key)),
+		Key:    aws.String(s.objectKey(key)),
 	})
 	return err
 }
+
+func (s *S3Storage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	out, err := s.Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if err != nil {
+		return nil, s3Error(err)
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(out.ContentLength),
+		ContentType:  aws.ToString(out.ContentType),
+		LastModified: aws.ToTime(out.LastModified),
+	}, nil
+}
+
+func (s *S3Storage) PresignedURL(ctx context.Context, method, key string, expiry time.Duration) (string, error) {
+	withExpiry := s3.WithPresignExpires(expiry)
+
+	switch method {
+	case http.MethodGet:
+		req, err := s.Presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(s.Bucket),
+			Key:    aws.String(s.objectKey(key)),
+		}, withExpiry)
+		if err != nil {
+			return "", err
+		}
+		return req.URL, nil
+	case http.MethodPut:
+		req, err := s.Presigner.PresignPutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(s.Bucket),
+			Key:    aws.String(s.objectKey(key)),
+		}, withExpiry)
+		if err != nil {
+			return "", err
+		}
+		return req.URL, nil
+	default:
+		return "", fmt.Errorf("unsupported presign method %q", method)
+	}
+}
+
+func (s *S3Storage) URL(key string) string {
+	return fmt.Sprintf("%s/%s", s.BaseURL, s.objectKey(key))
+}
+
+func (s *S3Storage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	paginator := s3.NewListObjectsV2Paginator(s.Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.Bucket),
+		Prefix: aws.String(s.objectKey(prefix)),
+	})
+
+	var objects []ObjectInfo
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range page.Contents {
+			key := aws.ToString(object.Key)
+			if s.FolderPath != "" {
+				key = strings.TrimPrefix(key, s.FolderPath+"/")
+			}
+			objects = append(objects, ObjectInfo{
+				Key:          key,
+				Size:         aws.ToInt64(object.Size),
+				LastModified: aws.ToTime(object.LastModified),
+			})
+		}
+	}
+	return objects, nil
+}
+
+func (s *S3Storage) objectKey(key string) string {
+	if s.FolderPath == "" {
+		return key
+	}
+	return s.FolderPath + "/" + key
+}
+
+func s3Error(err error) error {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+		return ErrObjectNotFound.SetCause(err)
+	}
+	return err
+}
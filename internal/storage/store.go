@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"CookFinder.Backend/pkg/puberr"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrObjectNotFound    = puberr.NewPubErr("object not found").SetHTTPCode(http.StatusNotFound)
+	ErrInvalidKey        = puberr.NewPubErr("invalid object key")
+	ErrPresignNotAllowed = puberr.NewPubErr("presigned uploads are not supported by this storage backend").SetHTTPCode(http.StatusNotImplemented)
+)
+
+// ObjectInfo — метаданные объекта в хранилище
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// ObjectStore — хранилище объектов; ключи имеют вид "<file id>/<name>"
+type ObjectStore interface {
+	// Put сохраняет объект и возвращает его публичный URL
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) (string, error)
+	// Get открывает объект на чтение; вызывающий закрывает reader
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	// Stat возвращает ErrObjectNotFound, если объекта нет
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	// PresignedURL выдаёт временную ссылку для method (GET или PUT) без авторизации в API
+	PresignedURL(ctx context.Context, method, key string, expiry time.Duration) (string, error)
+	// URL — публичный адрес объекта
+	URL(key string) string
+	// List перечисляет объекты с ключами, начинающимися с prefix
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+}
+
+var (
+	_ ObjectStore = (*YandexStorage)(nil)
+	_ ObjectStore = (*S3Storage)(nil)
+	_ ObjectStore = (*LocalStorage)(nil)
+	_ ObjectStore = (*MemoryStorage)(nil)
+)
+
+// Бэкенды хранилища, выбираются переменной STORAGE_BACKEND
+const (
+	BackendYandex = "yandex"
+	BackendS3     = "s3"
+	BackendLocal  = "local"
+	BackendMemory = "memory"
+)
+
+type Config struct {
+	Backend   string
+	Endpoint  string
+	Region    string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	// PublicURL — префикс публичных ссылок; для local по умолчанию /static
+	PublicURL string
+}
+
+// New создаёт хранилище по конфигурации; пустой Backend означает yandex
+func New(cfg Config) (ObjectStore, error) {
+	switch cfg.Backend {
+	case "", BackendYandex:
+		return NewYandexStorage(cfg.Endpoint, cfg.AccessKey, cfg.SecretKey, cfg.Bucket)
+	case BackendS3:
+		return NewS3StorageFromConfig(cfg), nil
+	case BackendLocal:
+		return NewLocalStorage(LocalDir, cfg.PublicURL)
+	case BackendMemory:
+		return NewMemoryStorage(cfg.PublicURL), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"io"
 	"log/slog"
-	"mime/multipart"
+	"net/http"
 	"time"
 )
 
@@ -21,6 +22,8 @@ func NewYandexStorage(endpoint, accessKey, secretKey, bucket string) (*YandexSto
 	client, err := minio.New(endpoint, &minio.Options{
 		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
 		Secure: true,
+		// регион задан явно, иначе для подписи ссылок minio запрашивает его у бакета по сети
+		Region: "ru-central1",
 	})
 	if err != nil {
 		return nil, err
@@ -32,26 +35,82 @@ func NewYandexStorage(endpoint, accessKey, secretKey, bucket string) (*YandexSto
 	}, nil
 }
 
-func (it *YandexStorage) UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
-	objectName := fmt.Sprintf("%d_%s", time.Now().UnixNano(), fileHeader.Filename)
-
-	// Upload
-	_, err := it.client.PutObject(ctx, it.bucketName, objectName, file, fileHeader.Size, minio.PutObjectOptions{
-		ContentType: fileHeader.Header.Get("Content-Type"),
+func (it *YandexStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) (string, error) {
+	_, err := it.client.PutObject(ctx, it.bucketName, key, reader, size, minio.PutObjectOptions{
+		ContentType: contentType,
 	})
 	if err != nil {
 		return "", err
 	}
+	return it.URL(key), nil
+}
 
-	// Public URL
-	url := fmt.Sprintf("https://%s/%s/%s", it.endpoint, it.bucketName, objectName)
-	return url, nil
+func (it *YandexStorage) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	object, err := it.client.GetObject(ctx, it.bucketName, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, minioError(err)
+	}
+
+	// GetObject не обращается к хранилищу до первого чтения, Stat проверяет, что объект есть
+	stat, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, nil, minioError(err)
+	}
+	return object, newMinioObjectInfo(stat), nil
 }
 
-func (it *YandexStorage) DeleteFile(ctx context.Context, objectName string) error {
-	err := it.client.RemoveObject(ctx, it.bucketName, objectName, minio.RemoveObjectOptions{})
+func (it *YandexStorage) Delete(ctx context.Context, key string) error {
+	err := it.client.RemoveObject(ctx, it.bucketName, key, minio.RemoveObjectOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to delete file from Yandex Cloud: %w", err)
 	}
 	return nil
 }
+
+func (it *YandexStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	stat, err := it.client.StatObject(ctx, it.bucketName, key, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, minioError(err)
+	}
+	return newMinioObjectInfo(stat), nil
+}
+
+func (it *YandexStorage) PresignedURL(ctx context.Context, method, key string, expiry time.Duration) (string, error) {
+	u, err := it.client.Presign(ctx, method, it.bucketName, key, expiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func (it *YandexStorage) URL(key string) string {
+	return fmt.Sprintf("https://%s/%s/%s", it.endpoint, it.bucketName, key)
+}
+
+func (it *YandexStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	for object := range it.client.ListObjects(ctx, it.bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		objects = append(objects, *newMinioObjectInfo(object))
+	}
+	return objects, nil
+}
+
+func newMinioObjectInfo(stat minio.ObjectInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Key:          stat.Key,
+		Size:         stat.Size,
+		ContentType:  stat.ContentType,
+		LastModified: stat.LastModified,
+	}
+}
+
+func minioError(err error) error {
+	if resp := minio.ToErrorResponse(err); resp.Code == "NoSuchKey" || resp.StatusCode == http.StatusNotFound {
+		return ErrObjectNotFound.SetCause(err)
+	}
+	return err
+}
@@ -0,0 +1,93 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE recipes
+    ADD COLUMN search_vector tsvector NOT NULL DEFAULT ''::tsvector;
+
+-- Документ для поиска: название (A), ингредиенты (B) и способ приготовления (C)
+-- со стеммингом и для русского, и для английского языка.
+CREATE FUNCTION recipe_search_document(title TEXT, method TEXT, ingredient_names TEXT) RETURNS tsvector AS
+$$
+SELECT setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+       setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+       setweight(to_tsvector('russian', coalesce(ingredient_names, '')), 'B') ||
+       setweight(to_tsvector('english', coalesce(ingredient_names, '')), 'B') ||
+       setweight(to_tsvector('russian', coalesce(method, '')), 'C') ||
+       setweight(to_tsvector('english', coalesce(method, '')), 'C')
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE FUNCTION recipe_ingredient_names(p_recipe_id VARCHAR) RETURNS TEXT AS
+$$
+SELECT string_agg(i.name, ' ')
+FROM recipe_ingredients ri
+         JOIN ingredients i ON i.id = ri.ingredient_id
+WHERE ri.recipe_id = p_recipe_id
+$$ LANGUAGE sql STABLE;
+
+CREATE FUNCTION recipes_search_vector_trigger() RETURNS trigger AS
+$$
+BEGIN
+    NEW.search_vector := recipe_search_document(NEW.title, NEW.method, recipe_ingredient_names(NEW.id));
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION recipe_ingredients_search_vector_trigger() RETURNS trigger AS
+$$
+DECLARE
+    v_recipe_id VARCHAR := CASE WHEN TG_OP = 'DELETE' THEN OLD.recipe_id ELSE NEW.recipe_id END;
+BEGIN
+    UPDATE recipes
+    SET search_vector = recipe_search_document(title, method, recipe_ingredient_names(id))
+    WHERE id = v_recipe_id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION ingredients_search_vector_trigger() RETURNS trigger AS
+$$
+BEGIN
+    UPDATE recipes
+    SET search_vector = recipe_search_document(title, method, recipe_ingredient_names(id))
+    WHERE id IN (SELECT recipe_id FROM recipe_ingredients WHERE ingredient_id = NEW.id);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER recipes_search_vector
+    BEFORE INSERT OR UPDATE OF title, method
+    ON recipes
+    FOR EACH ROW
+EXECUTE FUNCTION recipes_search_vector_trigger();
+
+CREATE TRIGGER recipe_ingredients_search_vector
+    AFTER INSERT OR UPDATE OR DELETE
+    ON recipe_ingredients
+    FOR EACH ROW
+EXECUTE FUNCTION recipe_ingredients_search_vector_trigger();
+
+CREATE TRIGGER ingredients_search_vector
+    AFTER UPDATE OF name
+    ON ingredients
+    FOR EACH ROW
+EXECUTE FUNCTION ingredients_search_vector_trigger();
+
+UPDATE recipes
+SET search_vector = recipe_search_document(title, method, recipe_ingredient_names(id));
+
+CREATE INDEX idx_recipes_search_vector ON recipes USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_recipes_search_vector;
+DROP TRIGGER IF EXISTS ingredients_search_vector ON ingredients;
+DROP TRIGGER IF EXISTS recipe_ingredients_search_vector ON recipe_ingredients;
+DROP TRIGGER IF EXISTS recipes_search_vector ON recipes;
+DROP FUNCTION IF EXISTS ingredients_search_vector_trigger();
+DROP FUNCTION IF EXISTS recipe_ingredients_search_vector_trigger();
+DROP FUNCTION IF EXISTS recipes_search_vector_trigger();
+DROP FUNCTION IF EXISTS recipe_ingredient_names(VARCHAR);
+DROP FUNCTION IF EXISTS recipe_search_document(TEXT, TEXT, TEXT);
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS search_vector;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE recipes
+    ADD COLUMN author_id VARCHAR(255) REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_recipes_author_id ON recipes (author_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_recipes_author_id;
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS author_id;
+-- +goose StatementEnd
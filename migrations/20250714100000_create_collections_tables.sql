@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE collections
+(
+    id         VARCHAR(255) PRIMARY KEY,
+    user_id    VARCHAR(255) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       TEXT         NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE collection_recipes
+(
+    collection_id VARCHAR(255) NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    recipe_id     VARCHAR(255) NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    position      INT          NOT NULL DEFAULT 0,
+    added_at      TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (collection_id, recipe_id)
+);
+
+CREATE INDEX idx_collection_recipes_recipe_id ON collection_recipes (recipe_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS collection_recipes;
+DROP TABLE IF EXISTS collections;
+-- +goose StatementEnd
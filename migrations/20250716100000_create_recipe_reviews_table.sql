@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE recipe_reviews
+(
+    recipe_id  VARCHAR(255) NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    user_id    VARCHAR(255) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    rating     SMALLINT     NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body       TEXT         NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (recipe_id, user_id)
+);
+
+-- Агрегаты хранятся в recipes и обновляются триггером в той же транзакции, что и отзыв:
+-- UPDATE блокирует строку рецепта, поэтому параллельные оценки не теряются.
+ALTER TABLE recipes
+    ADD COLUMN rating_sum   INT NOT NULL DEFAULT 0,
+    ADD COLUMN rating_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN avg_rating   DOUBLE PRECISION GENERATED ALWAYS AS (
+        CASE WHEN rating_count > 0 THEN rating_sum::DOUBLE PRECISION / rating_count ELSE 0 END
+        ) STORED;
+
+CREATE INDEX idx_recipes_avg_rating ON recipes (avg_rating, id);
+
+CREATE FUNCTION recipe_reviews_rating_trigger() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE recipes
+        SET rating_sum   = rating_sum + NEW.rating,
+            rating_count = rating_count + 1
+        WHERE id = NEW.recipe_id;
+    ELSIF TG_OP = 'UPDATE' THEN
+        UPDATE recipes
+        SET rating_sum = rating_sum + NEW.rating - OLD.rating
+        WHERE id = NEW.recipe_id;
+    ELSE
+        UPDATE recipes
+        SET rating_sum   = rating_sum - OLD.rating,
+            rating_count = rating_count - 1
+        WHERE id = OLD.recipe_id;
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER recipe_reviews_rating
+    AFTER INSERT OR UPDATE OF rating OR DELETE
+    ON recipe_reviews
+    FOR EACH ROW
+EXECUTE FUNCTION recipe_reviews_rating_trigger();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS recipe_reviews_rating ON recipe_reviews;
+DROP FUNCTION IF EXISTS recipe_reviews_rating_trigger();
+DROP INDEX IF EXISTS idx_recipes_avg_rating;
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS avg_rating,
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS rating_sum;
+DROP TABLE IF EXISTS recipe_reviews;
+-- +goose StatementEnd
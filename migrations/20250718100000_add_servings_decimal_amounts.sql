@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- energy, fat и protein рецепта относятся ко всем порциям сразу
+ALTER TABLE recipes
+    ADD COLUMN servings INT NOT NULL DEFAULT 1 CHECK (servings > 0);
+
+ALTER TABLE recipe_ingredients
+    ALTER COLUMN amount TYPE NUMERIC(10, 3);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipe_ingredients
+    ALTER COLUMN amount TYPE INTEGER USING round(amount)::INTEGER;
+
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS servings;
+-- +goose StatementEnd
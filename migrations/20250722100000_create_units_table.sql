@@ -0,0 +1,103 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE units
+(
+    code      VARCHAR(16) PRIMARY KEY,
+    name      VARCHAR(64)      NOT NULL,
+    dimension VARCHAR(16)      NOT NULL CHECK (dimension IN ('mass', 'volume', 'count')),
+    system    VARCHAR(16)      NOT NULL CHECK (system IN ('metric', 'imperial', 'any')),
+    -- множитель к базовой единице измерения: g для массы, ml для объёма, pcs для штук
+    to_base   DOUBLE PRECISION NOT NULL CHECK (to_base > 0)
+);
+
+CREATE TABLE unit_aliases
+(
+    alias     VARCHAR(32) PRIMARY KEY,
+    unit_code VARCHAR(16) NOT NULL REFERENCES units (code) ON DELETE CASCADE
+);
+
+INSERT INTO units (code, name, dimension, system, to_base)
+VALUES ('mg', 'milligram', 'mass', 'metric', 0.001),
+       ('g', 'gram', 'mass', 'metric', 1),
+       ('kg', 'kilogram', 'mass', 'metric', 1000),
+       ('oz', 'ounce', 'mass', 'imperial', 28.349523125),
+       ('lb', 'pound', 'mass', 'imperial', 453.59237),
+       ('ml', 'millilitre', 'volume', 'metric', 1),
+       ('l', 'litre', 'volume', 'metric', 1000),
+       ('tsp', 'teaspoon', 'volume', 'imperial', 4.92892159375),
+       ('tbsp', 'tablespoon', 'volume', 'imperial', 14.78676478125),
+       ('cup', 'cup', 'volume', 'imperial', 236.5882365),
+       ('pcs', 'piece', 'count', 'any', 1);
+
+INSERT INTO unit_aliases (alias, unit_code)
+VALUES ('мг', 'mg'),
+       ('milligram', 'mg'),
+       ('г', 'g'),
+       ('гр', 'g'),
+       ('гр.', 'g'),
+       ('грамм', 'g'),
+       ('граммов', 'g'),
+       ('gram', 'g'),
+       ('grams', 'g'),
+       ('кг', 'kg'),
+       ('килограмм', 'kg'),
+       ('kilogram', 'kg'),
+       ('унция', 'oz'),
+       ('ounce', 'oz'),
+       ('фунт', 'lb'),
+       ('lbs', 'lb'),
+       ('pound', 'lb'),
+       ('мл', 'ml'),
+       ('миллилитр', 'ml'),
+       ('millilitre', 'ml'),
+       ('milliliter', 'ml'),
+       ('л', 'l'),
+       ('литр', 'l'),
+       ('litre', 'l'),
+       ('liter', 'l'),
+       ('ч.л.', 'tsp'),
+       ('ч. л.', 'tsp'),
+       ('чайная ложка', 'tsp'),
+       ('teaspoon', 'tsp'),
+       ('ст.л.', 'tbsp'),
+       ('ст. л.', 'tbsp'),
+       ('столовая ложка', 'tbsp'),
+       ('tablespoon', 'tbsp'),
+       ('стакан', 'cup'),
+       ('cups', 'cup'),
+       ('шт', 'pcs'),
+       ('шт.', 'pcs'),
+       ('штука', 'pcs'),
+       ('штук', 'pcs'),
+       ('pc', 'pcs'),
+       ('piece', 'pcs'),
+       ('pieces', 'pcs');
+
+-- Приводим существующие записи к каноническим кодам
+UPDATE recipe_ingredients ri
+SET unit = a.unit_code
+FROM unit_aliases a
+WHERE lower(trim(ri.unit)) = a.alias;
+
+UPDATE recipe_ingredients
+SET unit = lower(trim(unit))
+WHERE lower(trim(unit)) IN (SELECT code FROM units);
+
+-- NOT VALID: нераспознанные старые значения остаются как есть, новые записи проверяются
+ALTER TABLE recipe_ingredients
+    ADD CONSTRAINT recipe_ingredients_unit_fkey FOREIGN KEY (unit) REFERENCES units (code) NOT VALID;
+
+-- Плотность нужна для пересчёта объёма в массу; 0 — неизвестна
+ALTER TABLE ingredients
+    ADD COLUMN density_g_per_ml DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (density_g_per_ml >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ingredients
+    DROP COLUMN IF EXISTS density_g_per_ml;
+ALTER TABLE recipe_ingredients
+    DROP CONSTRAINT IF EXISTS recipe_ingredients_unit_fkey;
+DROP TABLE IF EXISTS unit_aliases;
+DROP TABLE IF EXISTS units;
+-- +goose StatementEnd
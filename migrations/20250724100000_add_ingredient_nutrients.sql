@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Пищевая ценность на 100 г продукта
+ALTER TABLE ingredients
+    ADD COLUMN kcal_per_100g    DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (kcal_per_100g >= 0),
+    ADD COLUMN protein_per_100g DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (protein_per_100g >= 0),
+    ADD COLUMN fat_per_100g     DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (fat_per_100g >= 0),
+    ADD COLUMN carbs_per_100g   DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (carbs_per_100g >= 0),
+    ADD COLUMN fiber_per_100g   DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (fiber_per_100g >= 0),
+    ADD COLUMN sugar_per_100g   DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (sugar_per_100g >= 0),
+    ADD COLUMN salt_per_100g    DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (salt_per_100g >= 0),
+    -- масса одной штуки, чтобы считать ингредиенты в pcs; 0 — неизвестна
+    ADD COLUMN unit_weight_g    DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (unit_weight_g >= 0);
+
+-- Если true, energy, fat и protein рецепта введены вручную и не пересчитываются по ингредиентам
+ALTER TABLE recipes
+    ADD COLUMN nutrition_override BOOLEAN NOT NULL DEFAULT false;
+
+-- У существующих рецептов значения вводились вручную, а данных по ингредиентам ещё нет
+UPDATE recipes
+SET nutrition_override = true;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS nutrition_override;
+
+ALTER TABLE ingredients
+    DROP COLUMN IF EXISTS kcal_per_100g,
+    DROP COLUMN IF EXISTS protein_per_100g,
+    DROP COLUMN IF EXISTS fat_per_100g,
+    DROP COLUMN IF EXISTS carbs_per_100g,
+    DROP COLUMN IF EXISTS fiber_per_100g,
+    DROP COLUMN IF EXISTS sugar_per_100g,
+    DROP COLUMN IF EXISTS salt_per_100g,
+    DROP COLUMN IF EXISTS unit_weight_g;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE recipes
+    ADD COLUMN carbs  FLOAT NOT NULL DEFAULT 0,
+    ADD COLUMN fiber  FLOAT NOT NULL DEFAULT 0,
+    ADD COLUMN sugar  FLOAT NOT NULL DEFAULT 0,
+    -- натрий в миллиграммах, остальные макронутриенты в граммах
+    ADD COLUMN sodium FLOAT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipes
+    DROP COLUMN carbs,
+    DROP COLUMN fiber,
+    DROP COLUMN sugar,
+    DROP COLUMN sodium;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ingredients
+    ADD COLUMN allergens TEXT[]  NOT NULL DEFAULT '{}',
+    -- любой продукт животного происхождения (мясо, молоко, яйца, мёд)
+    ADD COLUMN is_animal BOOLEAN NOT NULL DEFAULT false,
+    -- мясо, птица, рыба и морепродукты
+    ADD COLUMN is_meat   BOOLEAN NOT NULL DEFAULT false,
+    ADD CONSTRAINT ingredients_meat_is_animal CHECK (NOT is_meat OR is_animal);
+
+CREATE INDEX idx_ingredients_allergens ON ingredients USING GIN (allergens);
+
+-- Ручные метки рецепта поверх выведенных из ингредиентов (например, keto или halal)
+ALTER TABLE recipes
+    ADD COLUMN diet_tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_recipes_diet_tags ON recipes USING GIN (diet_tags);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_recipes_diet_tags;
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS diet_tags;
+
+DROP INDEX IF EXISTS idx_ingredients_allergens;
+ALTER TABLE ingredients
+    DROP CONSTRAINT IF EXISTS ingredients_meat_is_animal,
+    DROP COLUMN IF EXISTS allergens,
+    DROP COLUMN IF EXISTS is_animal,
+    DROP COLUMN IF EXISTS is_meat;
+-- +goose StatementEnd
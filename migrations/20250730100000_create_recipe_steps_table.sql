@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE recipe_steps
+(
+    recipe_id     VARCHAR(255) NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    ordinal       INT          NOT NULL CHECK (ordinal > 0),
+    text          TEXT         NOT NULL,
+    duration_sec  INT CHECK (duration_sec > 0),
+    image_file_id VARCHAR(255) REFERENCES files (id) ON DELETE SET NULL,
+    PRIMARY KEY (recipe_id, ordinal)
+);
+
+-- Переносим method: строки становятся шагами, нумерация вида «1.», «2)» или «Шаг 3:» отбрасывается.
+-- Однострочный текст, начинающийся с «1.», делится перед каждым следующим номером.
+INSERT INTO recipe_steps (recipe_id, ordinal, text)
+SELECT r.id, row_number() OVER (PARTITION BY r.id ORDER BY l.n), l.text
+FROM recipes r
+         CROSS JOIN LATERAL (
+    SELECT t.n, trim(regexp_replace(t.line, '^\s*(шаг\s*)?\d+\s*[.):]\s*', '', 'i')) AS text
+    FROM regexp_split_to_table(
+                 r.method,
+                 CASE
+                     WHEN r.method !~ '\n' AND r.method ~ '^\s*1[.)]\s' THEN '\s+(?=\d+[.)]\s)'
+                     ELSE '\r?\n'
+                     END
+         ) WITH ORDINALITY AS t(line, n)
+    ) l
+WHERE l.text <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS recipe_steps;
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+-- recipes.category_id остаётся основной категорией рецепта для старых клиентов
+CREATE TABLE recipe_category_links
+(
+    recipe_id   VARCHAR(255) NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    category_id VARCHAR(255) NOT NULL REFERENCES recipe_categories (id) ON DELETE CASCADE,
+    position    INT          NOT NULL DEFAULT 0,
+    PRIMARY KEY (recipe_id, category_id)
+);
+
+CREATE INDEX idx_recipe_category_links_category_id ON recipe_category_links (category_id);
+
+-- удаление основной категории не должно удалять рецепт, который состоит и в других категориях:
+-- основной становится следующая из recipe_category_links, см. CategoryRepository.Delete
+ALTER TABLE recipes
+    DROP CONSTRAINT recipes_category_id_fkey,
+    ADD CONSTRAINT recipes_category_id_fkey FOREIGN KEY (category_id) REFERENCES recipe_categories (id) ON DELETE SET NULL;
+
+INSERT INTO recipe_category_links (recipe_id, category_id)
+SELECT id, category_id
+FROM recipes
+WHERE category_id IS NOT NULL;
+
+CREATE TABLE tags
+(
+    id   VARCHAR(255) PRIMARY KEY,
+    name TEXT        NOT NULL,
+    kind VARCHAR(32) NOT NULL DEFAULT 'other' CHECK (kind IN ('cuisine', 'meal_type', 'occasion', 'other')),
+    UNIQUE (kind, name)
+);
+
+CREATE TABLE recipe_tags
+(
+    recipe_id VARCHAR(255) NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    tag_id    VARCHAR(255) NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (recipe_id, tag_id)
+);
+
+CREATE INDEX idx_recipe_tags_tag_id ON recipe_tags (tag_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS recipe_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS recipe_category_links;
+ALTER TABLE recipes
+    DROP CONSTRAINT recipes_category_id_fkey,
+    ADD CONSTRAINT recipes_category_id_fkey FOREIGN KEY (category_id) REFERENCES recipe_categories (id) ON DELETE CASCADE;
+-- +goose StatementEnd
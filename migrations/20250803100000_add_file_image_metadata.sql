@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Имя файла — то, что прислал клиент, и оно не обязано быть уникальным (image.jpg с телефонов)
+ALTER TABLE files
+    DROP CONSTRAINT IF EXISTS files_name_key,
+    ADD COLUMN mime_type  TEXT  NOT NULL DEFAULT '',
+    ADD COLUMN width      INT   NOT NULL DEFAULT 0,
+    ADD COLUMN height     INT   NOT NULL DEFAULT 0,
+    ADD COLUMN renditions JSONB NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE files
+    DROP COLUMN IF EXISTS renditions,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS mime_type;
+-- +goose StatementEnd
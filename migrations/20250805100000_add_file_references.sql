@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE files
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX idx_files_path ON files (path);
+
+-- Картинки ссылаются на files; пока файл используется, удалить его нельзя.
+-- image_url остаётся для старых клиентов и заполняется из files.path.
+ALTER TABLE recipes
+    ADD COLUMN image_file_id VARCHAR(255) REFERENCES files (id) ON DELETE RESTRICT;
+ALTER TABLE ingredients
+    ADD COLUMN image_file_id VARCHAR(255) REFERENCES files (id) ON DELETE RESTRICT;
+ALTER TABLE recipe_categories
+    ADD COLUMN image_file_id VARCHAR(255) REFERENCES files (id) ON DELETE RESTRICT;
+
+ALTER TABLE recipe_steps
+    DROP CONSTRAINT IF EXISTS recipe_steps_image_file_id_fkey,
+    ADD CONSTRAINT recipe_steps_image_file_id_fkey FOREIGN KEY (image_file_id) REFERENCES files (id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_recipes_image_file_id ON recipes (image_file_id);
+CREATE INDEX idx_ingredients_image_file_id ON ingredients (image_file_id);
+CREATE INDEX idx_recipe_categories_image_file_id ON recipe_categories (image_file_id);
+CREATE INDEX idx_recipe_steps_image_file_id ON recipe_steps (image_file_id);
+
+-- Связываем существующие картинки с загруженными файлами по URL
+UPDATE recipes r
+SET image_file_id = f.id
+FROM files f
+WHERE r.image_url = f.path;
+
+UPDATE ingredients i
+SET image_file_id = f.id
+FROM files f
+WHERE i.image_url = f.path;
+
+UPDATE recipe_categories c
+SET image_file_id = f.id
+FROM files f
+WHERE c.image_url = f.path;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipe_steps
+    DROP CONSTRAINT IF EXISTS recipe_steps_image_file_id_fkey,
+    ADD CONSTRAINT recipe_steps_image_file_id_fkey FOREIGN KEY (image_file_id) REFERENCES files (id) ON DELETE SET NULL;
+DROP INDEX IF EXISTS idx_recipe_steps_image_file_id;
+
+ALTER TABLE recipe_categories DROP COLUMN IF EXISTS image_file_id;
+ALTER TABLE ingredients DROP COLUMN IF EXISTS image_file_id;
+ALTER TABLE recipes DROP COLUMN IF EXISTS image_file_id;
+
+DROP INDEX IF EXISTS idx_files_path;
+ALTER TABLE files DROP COLUMN IF EXISTS created_at;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ingredients
+    ADD COLUMN aisle VARCHAR(32) NOT NULL DEFAULT 'other'
+        CHECK (aisle IN ('produce', 'meat', 'fish', 'dairy', 'bakery', 'grocery', 'spices', 'frozen', 'drinks', 'other'));
+
+CREATE TABLE shopping_lists
+(
+    id          VARCHAR(255) PRIMARY KEY,
+    user_id     VARCHAR(255) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name        TEXT         NOT NULL,
+    -- токен ссылки только для чтения; NULL — список не расшарен
+    share_token VARCHAR(64) UNIQUE,
+    created_at  TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_shopping_lists_user_id ON shopping_lists (user_id);
+
+CREATE TABLE shopping_list_recipes
+(
+    list_id   VARCHAR(255) NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    recipe_id VARCHAR(255) NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    servings  INT          NOT NULL CHECK (servings > 0),
+    PRIMARY KEY (list_id, recipe_id)
+);
+
+-- Позиции сохраняются уже сведёнными: правка рецепта после составления списка его не меняет
+CREATE TABLE shopping_list_items
+(
+    id            VARCHAR(255) PRIMARY KEY,
+    list_id       VARCHAR(255)   NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    ingredient_id VARCHAR(255)   NOT NULL REFERENCES ingredients (id) ON DELETE CASCADE,
+    amount        NUMERIC(10, 3) NOT NULL,
+    unit          VARCHAR(50)    NOT NULL,
+    checked       BOOLEAN        NOT NULL DEFAULT FALSE,
+    UNIQUE (list_id, ingredient_id, unit)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shopping_list_items;
+DROP TABLE IF EXISTS shopping_list_recipes;
+DROP TABLE IF EXISTS shopping_lists;
+ALTER TABLE ingredients DROP COLUMN IF EXISTS aisle;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE meal_plan_entries
+(
+    id         VARCHAR(255) PRIMARY KEY,
+    user_id    VARCHAR(255) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    date       DATE         NOT NULL,
+    slot       VARCHAR(16)  NOT NULL CHECK (slot IN ('breakfast', 'lunch', 'dinner', 'snack')),
+    recipe_id  VARCHAR(255) NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    -- на сколько человек готовится блюдо; пищевая ценность рецепта пересчитывается на это число порций
+    servings   INT          NOT NULL CHECK (servings > 0),
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_meal_plan_entries_user_id_date ON meal_plan_entries (user_id, date);
+CREATE INDEX idx_meal_plan_entries_recipe_id ON meal_plan_entries (recipe_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS meal_plan_entries;
+-- +goose StatementEnd
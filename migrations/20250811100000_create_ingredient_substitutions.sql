@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Замена ингредиента ingredient_id на один или несколько других (например, пахта → молоко + лимонный сок)
+CREATE TABLE ingredient_substitutions
+(
+    id            VARCHAR(255) PRIMARY KEY,
+    ingredient_id VARCHAR(255) NOT NULL REFERENCES ingredients (id) ON DELETE CASCADE,
+    context       VARCHAR(32)  NOT NULL DEFAULT 'any'
+        CHECK (context IN ('any', 'baking', 'cooking', 'raw', 'sauce', 'drinks')),
+    -- замена работает и в обратную сторону; допустимо только для замены одним ингредиентом
+    bidirectional BOOLEAN      NOT NULL DEFAULT FALSE,
+    notes         TEXT         NOT NULL DEFAULT '',
+    created_at    TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_ingredient_substitutions_ingredient_id ON ingredient_substitutions (ingredient_id);
+
+-- ratio — сколько заменителя взять на единицу исходного ингредиента в той же единице измерения
+CREATE TABLE ingredient_substitution_parts
+(
+    substitution_id VARCHAR(255)   NOT NULL REFERENCES ingredient_substitutions (id) ON DELETE CASCADE,
+    ingredient_id   VARCHAR(255)   NOT NULL REFERENCES ingredients (id) ON DELETE CASCADE,
+    ratio           NUMERIC(10, 3) NOT NULL CHECK (ratio > 0),
+    position        INT            NOT NULL DEFAULT 0,
+    PRIMARY KEY (substitution_id, ingredient_id)
+);
+
+CREATE INDEX idx_ingredient_substitution_parts_ingredient_id ON ingredient_substitution_parts (ingredient_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ingredient_substitution_parts;
+DROP TABLE IF EXISTS ingredient_substitutions;
+-- +goose StatementEnd
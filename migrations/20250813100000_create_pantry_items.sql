@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Запасы пользователя; одного ингредиента может быть несколько партий с разным сроком годности
+CREATE TABLE pantry_items
+(
+    id            VARCHAR(255)   PRIMARY KEY,
+    user_id       VARCHAR(255)   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    ingredient_id VARCHAR(255)   NOT NULL REFERENCES ingredients (id) ON DELETE CASCADE,
+    quantity      NUMERIC(10, 3) NOT NULL CHECK (quantity > 0),
+    unit          VARCHAR(32)    NOT NULL,
+    expires_on    DATE,
+    created_at    TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX idx_pantry_items_user_id_expires_on ON pantry_items (user_id, expires_on);
+CREATE INDEX idx_pantry_items_ingredient_id ON pantry_items (ingredient_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pantry_items;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ингредиенты, которые не удалось перевести в граммы при расчёте пищевой ценности
+-- (например, штуки без unit_weight_g); непустой массив — значения рецепта занижены
+ALTER TABLE recipes
+    ADD COLUMN nutrition_skipped TEXT[] NOT NULL DEFAULT '{}';
+
+-- Рецепты, рассчитанные до появления колонки: штуки без известной массы одной штуки
+UPDATE recipes r
+SET nutrition_skipped = ARRAY(SELECT DISTINCT ri.ingredient_id
+                              FROM recipe_ingredients ri
+                                       JOIN ingredients i ON i.id = ri.ingredient_id
+                                       JOIN units u ON u.code = ri.unit
+                              WHERE ri.recipe_id = r.id
+                                AND u.dimension = 'count'
+                                AND i.unit_weight_g = 0)
+WHERE NOT r.nutrition_override;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipes
+    DROP COLUMN IF EXISTS nutrition_skipped;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- allergens, is_animal и is_meat проверены. У непроверенного ингредиента пустой allergens и false
+-- означают «неизвестно», поэтому рецепт с ним не получает выводимых меток и не проходит фильтры по диете и аллергенам.
+ALTER TABLE ingredients
+    ADD COLUMN allergens_reviewed BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ingredients
+    DROP COLUMN IF EXISTS allergens_reviewed;
+-- +goose StatementEnd
@@ -3,15 +3,17 @@ package dto
 import "CookFinder.Backend/internal/model"
 
 type Category struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	ImageURL string `json:"image_url"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ImageURL    string `json:"image_url"`
+	ImageFileID string `json:"image_file_id,omitempty"` // ID загруженного файла, image_url берётся из него
 }
 
 func NewCategoryFromModel(category *model.Category) *Category {
 	return &Category{
-		ID:       category.ID,
-		Name:     category.Name,
-		ImageURL: category.ImageUrl,
+		ID:          category.ID,
+		Name:        category.Name,
+		ImageURL:    category.ImageUrl,
+		ImageFileID: category.ImageFileID,
 	}
 }
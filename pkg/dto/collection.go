@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"CookFinder.Backend/internal/model"
+	"time"
+)
+
+type CollectionRequest struct {
+	Name string `json:"name"`
+}
+
+type CollectionRecipeRequest struct {
+	RecipeID string `json:"recipe_id"`
+}
+
+type CollectionOrderRequest struct {
+	RecipeIDs []string `json:"recipe_ids"`
+}
+
+type CollectionResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	RecipeCount int       `json:"recipe_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewCollectionFromModel(collection *model.Collection) *CollectionResponse {
+	return &CollectionResponse{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		RecipeCount: collection.RecipeCount,
+		CreatedAt:   collection.CreatedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"CookFinder.Backend/internal/model"
+	"time"
+)
+
+type PresignUploadRequest struct {
+	FileName    string `json:"file_name" binding:"required"`
+	ContentType string `json:"content_type" binding:"required" enums:"image/jpeg,image/png,image/webp,image/heic"`
+	Size        int64  `json:"size" binding:"required"` // байты
+}
+
+// PresignUploadResponse — исходник загружается PUT-запросом на upload_url с заголовком Content-Type,
+// затем ключ передаётся в POST /files/complete
+type PresignUploadResponse struct {
+	Key         string    `json:"key"`
+	UploadURL   string    `json:"upload_url"`
+	Method      string    `json:"method"`
+	ContentType string    `json:"content_type"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func NewPresignUploadFromModel(upload *model.PresignedUpload) *PresignUploadResponse {
+	return &PresignUploadResponse{
+		Key:         upload.Key,
+		UploadURL:   upload.URL,
+		Method:      "PUT",
+		ContentType: upload.ContentType,
+		ExpiresAt:   upload.ExpiresAt,
+	}
+}
+
+type CompleteUploadRequest struct {
+	Key string `json:"key" binding:"required"`
+}
+
+type SweepReportResponse struct {
+	DryRun         bool         `json:"dry_run"`
+	Before         time.Time    `json:"before"`
+	Files          []model.File `json:"files"`
+	Objects        []string     `json:"objects"`
+	DeletedFiles   int          `json:"deleted_files"`
+	DeletedObjects int          `json:"deleted_objects"`
+}
+
+func NewSweepReportFromModel(report *model.SweepReport) *SweepReportResponse {
+	return &SweepReportResponse{
+		DryRun:         report.DryRun,
+		Before:         report.Before,
+		Files:          report.Files,
+		Objects:        report.Objects,
+		DeletedFiles:   report.DeletedFiles,
+		DeletedObjects: report.DeletedObjects,
+	}
+}
@@ -3,21 +3,83 @@ package dto
 import "CookFinder.Backend/internal/model"
 
 type IngredientRequest struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	ImageUrl string `json:"image_url"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	ImageUrl      string    `json:"image_url"`
+	ImageFileID   string    `json:"image_file_id"` // ID загруженного файла, image_url берётся из него
+	DensityGPerMl float64   `json:"density_g_per_ml"`
+	UnitWeightG   float64   `json:"unit_weight_g"`
+	Nutrients     Nutrients `json:"nutrients"`
+	Allergens     []string  `json:"allergens"`
+	IsAnimal      bool      `json:"is_animal"`
+	IsMeat        bool      `json:"is_meat"` // мясо, птица, рыба, морепродукты
+	// AllergensReviewed подтверждает, что allergens, is_animal и is_meat заполнены; без него рецепты
+	// с ингредиентом не получают меток vegan, *-free и не проходят фильтры diet и exclude_allergens
+	AllergensReviewed bool   `json:"allergens_reviewed"`
+	Aisle             string `json:"aisle" enums:"produce,meat,fish,dairy,bakery,grocery,spices,frozen,drinks,other"` // по умолчанию other
 }
 
 type IngredientResponse struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	ImageUrl string `json:"image_url"`
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	ImageUrl          string    `json:"image_url"`
+	ImageFileID       string    `json:"image_file_id,omitempty"`
+	DensityGPerMl     float64   `json:"density_g_per_ml,omitempty"`
+	UnitWeightG       float64   `json:"unit_weight_g,omitempty"`
+	Nutrients         Nutrients `json:"nutrients"`
+	Allergens         []string  `json:"allergens"`
+	IsAnimal          bool      `json:"is_animal"`
+	IsMeat            bool      `json:"is_meat"`
+	AllergensReviewed bool      `json:"allergens_reviewed"`
+	Aisle             string    `json:"aisle"`
+}
+
+// Nutrients — пищевая ценность на 100 г продукта
+type Nutrients struct {
+	Kcal    float64 `json:"kcal"`
+	Protein float64 `json:"protein"`
+	Fat     float64 `json:"fat"`
+	Carbs   float64 `json:"carbs"`
+	Fiber   float64 `json:"fiber"`
+	Sugar   float64 `json:"sugar"`
+	Salt    float64 `json:"salt"`
 }
 
 func NewIngredientFromModel(ingredient *model.Ingredient) *IngredientResponse {
 	return &IngredientResponse{
-		ID:       ingredient.ID,
-		Name:     ingredient.Name,
-		ImageUrl: ingredient.ImageUrl,
+		ID:                ingredient.ID,
+		Name:              ingredient.Name,
+		ImageUrl:          ingredient.ImageUrl,
+		ImageFileID:       ingredient.ImageFileID,
+		DensityGPerMl:     ingredient.DensityGPerMl,
+		UnitWeightG:       ingredient.UnitWeightG,
+		Nutrients:         Nutrients(ingredient.Nutrients),
+		Allergens:         nonNilStrings(ingredient.Allergens),
+		IsAnimal:          ingredient.IsAnimal,
+		IsMeat:            ingredient.IsMeat,
+		AllergensReviewed: ingredient.AllergensReviewed,
+		Aisle:             ingredient.Aisle,
+	}
+}
+
+type IngredientSuggestionResponse struct {
+	IngredientResponse
+	Score  float64 `json:"score"`
+	Prefix bool    `json:"prefix"`
+}
+
+func NewIngredientSuggestionFromModel(suggestion *model.IngredientSuggestion) *IngredientSuggestionResponse {
+	return &IngredientSuggestionResponse{
+		IngredientResponse: *NewIngredientFromModel(&suggestion.Ingredient),
+		Score:              suggestion.Score,
+		Prefix:             suggestion.Prefix,
+	}
+}
+
+// nonNilStrings отдаёт пустой срез вместо nil, чтобы в JSON был [] а не null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
 	}
+	return values
 }
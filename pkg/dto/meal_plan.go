@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"CookFinder.Backend/internal/model"
+	"math"
+	"time"
+)
+
+type MealPlanEntryRequest struct {
+	Date     string `json:"date" binding:"required" example:"2025-08-04"` // YYYY-MM-DD
+	Slot     string `json:"slot" binding:"required" enums:"breakfast,lunch,dinner,snack"`
+	RecipeID string `json:"recipe_id" binding:"required"`
+	Servings int    `json:"servings"` // 0 — на сколько порций написан рецепт
+}
+
+type MealPlanEntryResponse struct {
+	ID             string          `json:"id"`
+	Date           string          `json:"date"`
+	Slot           string          `json:"slot"`
+	RecipeID       string          `json:"recipe_id"`
+	RecipeTitle    string          `json:"recipe_title"`
+	RecipeImageURL string          `json:"recipe_image_url"`
+	Servings       int             `json:"servings"`
+	Nutrition      RecipeNutrition `json:"nutrition"` // на servings порций
+}
+
+func NewMealPlanEntryFromModel(entry *model.MealPlanEntry) *MealPlanEntryResponse {
+	return &MealPlanEntryResponse{
+		ID:             entry.ID,
+		Date:           entry.Date.Format(time.DateOnly),
+		Slot:           entry.Slot,
+		RecipeID:       entry.RecipeID,
+		RecipeTitle:    entry.RecipeTitle,
+		RecipeImageURL: entry.RecipeImageURL,
+		Servings:       entry.Servings,
+		Nutrition:      newMealNutrition(entry.MealNutrition),
+	}
+}
+
+type MealPlanDayResponse struct {
+	Date    string                  `json:"date"`
+	Entries []MealPlanEntryResponse `json:"entries"`
+	Totals  RecipeNutrition         `json:"totals"`
+}
+
+type MealPlanWeekResponse struct {
+	Start  string                `json:"start"`
+	End    string                `json:"end"`
+	Days   []MealPlanDayResponse `json:"days"`
+	Totals RecipeNutrition       `json:"totals"`
+}
+
+func NewMealPlanWeekFromModel(week *model.MealPlanWeek) *MealPlanWeekResponse {
+	days := make([]MealPlanDayResponse, 0, len(week.Days))
+	for _, day := range week.Days {
+		entries := make([]MealPlanEntryResponse, 0, len(day.Entries))
+		for _, entry := range day.Entries {
+			entries = append(entries, *NewMealPlanEntryFromModel(&entry))
+		}
+		days = append(days, MealPlanDayResponse{
+			Date:    day.Date.Format(time.DateOnly),
+			Entries: entries,
+			Totals:  newMealNutrition(day.Totals),
+		})
+	}
+
+	end := week.Start
+	if len(week.Days) > 0 {
+		end = week.Days[len(week.Days)-1].Date
+	}
+	return &MealPlanWeekResponse{
+		Start:  week.Start.Format(time.DateOnly),
+		End:    end.Format(time.DateOnly),
+		Days:   days,
+		Totals: newMealNutrition(week.Totals),
+	}
+}
+
+func newMealNutrition(n model.MealNutrition) RecipeNutrition {
+	return RecipeNutrition{
+		Energy:  int(math.Round(n.Energy)),
+		Fat:     math.Round(n.Fat*10) / 10,
+		Protein: math.Round(n.Protein*10) / 10,
+		Carbs:   math.Round(n.Carbs*10) / 10,
+		Fiber:   math.Round(n.Fiber*10) / 10,
+		Sugar:   math.Round(n.Sugar*10) / 10,
+		Sodium:  math.Round(n.Sodium),
+	}
+}
+
+type MealPlanGenerateRequest struct {
+	Start              string   `json:"start" example:"2025-08-04"` // YYYY-MM-DD, по умолчанию понедельник текущей недели
+	Slots              []string `json:"slots" enums:"breakfast,lunch,dinner,snack"`
+	Servings           int      `json:"servings"`                          // порций каждого блюда, по умолчанию 1
+	EnergyPerDay       float64  `json:"energy_per_day" binding:"required"` // ккал на человека
+	ProteinPercent     float64  `json:"protein_percent"`                   // доли энергии, в сумме 100; не заданы — без ограничения
+	FatPercent         float64  `json:"fat_percent"`
+	CarbsPercent       float64  `json:"carbs_percent"`
+	Diet               []string `json:"diet"`
+	ExcludeAllergens   []string `json:"exclude_allergens"`
+	ExcludeIngredients []string `json:"exclude_ingredients"`
+	NoRepeatDays       int      `json:"no_repeat_days"` // по умолчанию 3
+	Seed               int64    `json:"seed"`           // 0 — случайный, выбранный возвращается в ответе
+	Save               bool     `json:"save"`           // сразу записать план в календарь вместо блюд в тех же приёмах пищи недели
+}
+
+type GeneratedMealPlanResponse struct {
+	MealPlanWeekResponse
+	Seed   int64           `json:"seed"`
+	Target RecipeNutrition `json:"target"` // цель на день для всех порций
+	Saved  bool            `json:"saved"`
+}
+
+func NewGeneratedMealPlanFromModel(plan *model.GeneratedMealPlan) *GeneratedMealPlanResponse {
+	return &GeneratedMealPlanResponse{
+		MealPlanWeekResponse: *NewMealPlanWeekFromModel(&plan.MealPlanWeek),
+		Seed:                 plan.Seed,
+		Target:               newMealNutrition(plan.Target),
+		Saved:                plan.Saved,
+	}
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "CookFinder.Backend/internal/model"
+
+// Page — общий конверт для списков; Items всегда слайс DTO конкретного списка
+type Page struct {
+	Items      any    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func NewPageFromModel[M any, T any](page model.Page[M], convert func(*M) *T) Page {
+	items := make([]T, 0, len(page.Items))
+	for i := range page.Items {
+		items = append(items, *convert(&page.Items[i]))
+	}
+
+	return Page{
+		Items:      items,
+		NextCursor: page.NextCursor,
+	}
+}
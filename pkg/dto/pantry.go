@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"CookFinder.Backend/internal/model"
+	"time"
+)
+
+type PantryItemRequest struct {
+	IngredientID string  `json:"ingredient_id" binding:"required"`
+	Quantity     float64 `json:"quantity" binding:"required" example:"500"`
+	Unit         string  `json:"unit" binding:"required" example:"g"`
+	ExpiresOn    string  `json:"expires_on" example:"2025-08-20"` // YYYY-MM-DD, пусто — срок не указан
+}
+
+type PantryItemResponse struct {
+	ID           string    `json:"id"`
+	IngredientID string    `json:"ingredient_id"`
+	Name         string    `json:"name"`
+	ImageURL     string    `json:"image_url"`
+	Quantity     float64   `json:"quantity"`
+	Unit         string    `json:"unit"`
+	ExpiresOn    string    `json:"expires_on,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func NewPantryItemFromModel(item *model.PantryItem) *PantryItemResponse {
+	response := &PantryItemResponse{
+		ID:           item.ID,
+		IngredientID: item.IngredientID,
+		Name:         item.Name,
+		ImageURL:     item.ImageURL,
+		Quantity:     item.Quantity,
+		Unit:         item.Unit,
+		CreatedAt:    item.CreatedAt,
+	}
+	if item.ExpiresOn != nil {
+		response.ExpiresOn = item.ExpiresOn.Format(time.DateOnly)
+	}
+	return response
+}
+
+type ExpiringMatchResponse struct {
+	RecipeMatchResponse
+	ExpiringCount int                        `json:"expiring_count"`
+	SoonestExpiry string                     `json:"soonest_expiry"`
+	Expiring      []RecipeIngredientResponse `json:"expiring"` // ингредиенты рецепта из запасов с истекающим сроком
+}
+
+func NewExpiringMatchFromModel(match *model.ExpiringMatch) *ExpiringMatchResponse {
+	return &ExpiringMatchResponse{
+		RecipeMatchResponse: *NewRecipeMatchResponseFromModel(&match.RecipeMatch),
+		ExpiringCount:       match.ExpiringCount,
+		SoonestExpiry:       match.SoonestExpiry.Format(time.DateOnly),
+		Expiring:            NewRecipeIngredientsFromModel(match.Expiring),
+	}
+}
+
+type CookedReportResponse struct {
+	RecipeID string                `json:"recipe_id"`
+	Servings int                   `json:"servings"`
+	Usage    []PantryUsageResponse `json:"usage"`
+}
+
+type PantryUsageResponse struct {
+	IngredientID string  `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Unit         string  `json:"unit"`
+	Required     float64 `json:"required"`
+	Consumed     float64 `json:"consumed"` // списано из запасов
+	Missing      float64 `json:"missing"`  // не нашлось в запасах
+}
+
+func NewCookedReportFromModel(report *model.CookedReport) *CookedReportResponse {
+	usage := make([]PantryUsageResponse, len(report.Usage))
+	for i, u := range report.Usage {
+		usage[i] = PantryUsageResponse{
+			IngredientID: u.IngredientID,
+			Name:         u.Name,
+			Unit:         u.Unit,
+			Required:     u.Required,
+			Consumed:     u.Consumed,
+			Missing:      max(u.Required-u.Consumed, 0),
+		}
+	}
+
+	return &CookedReportResponse{
+		RecipeID: report.RecipeID,
+		Servings: report.Servings,
+		Usage:    usage,
+	}
+}
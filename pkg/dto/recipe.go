@@ -2,63 +2,182 @@ package dto
 
 import (
 	"CookFinder.Backend/internal/model"
+	"math"
 	"time"
 )
 
 type RecipeResponse struct {
-	ID          string                     `json:"id"`
-	Title       string                     `json:"title"`
-	PrepTimeMin int                        `json:"prep_time_min"`
-	CookTimeMin int                        `json:"cook_time_min"`
-	Method      string                     `json:"method"`
-	ImageURL    string                     `json:"image_url"`
-	Energy      int                        `json:"energy"`
-	Fat         float64                    `json:"fat"`
-	Protein     float64                    `json:"protein"`
-	CreatedAt   time.Time                  `json:"created_at"`
-	Category    *Category                  `json:"category"`
-	Ingredients []RecipeIngredientResponse `json:"ingredients"`
+	ID                string          `json:"id"`
+	Title             string          `json:"title"`
+	PrepTimeMin       int             `json:"prep_time_min"`
+	CookTimeMin       int             `json:"cook_time_min"`
+	Method            string          `json:"method"`
+	ImageURL          string          `json:"image_url"`
+	ImageFileID       string          `json:"image_file_id,omitempty"`
+	Energy            int             `json:"energy"`
+	Fat               float64         `json:"fat"`
+	Protein           float64         `json:"protein"`
+	Carbs             float64         `json:"carbs"`
+	Fiber             float64         `json:"fiber"`
+	Sugar             float64         `json:"sugar"`
+	Sodium            float64         `json:"sodium"` // мг
+	Servings          int             `json:"servings"`
+	PerServing        RecipeNutrition `json:"per_serving"`
+	NutritionOverride bool            `json:"nutrition_override"`
+	// NutritionIncomplete — часть ингредиентов (nutrition_skipped) не попала в расчёт, и значения занижены
+	NutritionIncomplete bool                       `json:"nutrition_incomplete"`
+	NutritionSkipped    []string                   `json:"nutrition_skipped"`
+	CreatedAt           time.Time                  `json:"created_at"`
+	AuthorID            string                     `json:"author_id,omitempty"`
+	AvgRating           float64                    `json:"avg_rating"`
+	RatingCount         int                        `json:"rating_count"`
+	Category            *Category                  `json:"category"` // основная категория, для старых клиентов
+	Categories          []Category                 `json:"categories"`
+	Tags                []Tag                      `json:"tags"`
+	Ingredients         []RecipeIngredientResponse `json:"ingredients"`
+	Steps               []RecipeStepResponse       `json:"steps,omitempty"` // только в GET /recipes/{id}
+	IsFavorite          bool                       `json:"is_favorite"`
+	Diet                []string                   `json:"diet"` // выведенные из ингредиентов и ручные метки
+	Rank                float64                    `json:"rank,omitempty"`
+	Highlight           *RecipeHighlight           `json:"highlight,omitempty"`
+}
+
+// RecipeHighlight — фрагменты с найденными словами, выделенными <b></b>
+type RecipeHighlight struct {
+	Title  string `json:"title"`
+	Method string `json:"method"`
+}
+
+// RecipeNutrition — пищевая ценность одной порции
+type RecipeNutrition struct {
+	Energy  int     `json:"energy"`
+	Fat     float64 `json:"fat"`
+	Protein float64 `json:"protein"`
+	Carbs   float64 `json:"carbs"`
+	Fiber   float64 `json:"fiber"`
+	Sugar   float64 `json:"sugar"`
+	Sodium  float64 `json:"sodium"`
+}
+
+func newPerServingNutrition(recipe *model.Recipe) RecipeNutrition {
+	servings := float64(recipe.Servings)
+	if servings <= 0 {
+		servings = 1
+	}
+	return RecipeNutrition{
+		Energy:  int(math.Round(float64(recipe.Energy) / servings)),
+		Fat:     math.Round(recipe.Fat/servings*10) / 10,
+		Protein: math.Round(recipe.Protein/servings*10) / 10,
+		Carbs:   math.Round(recipe.Carbs/servings*10) / 10,
+		Fiber:   math.Round(recipe.Fiber/servings*10) / 10,
+		Sugar:   math.Round(recipe.Sugar/servings*10) / 10,
+		Sodium:  math.Round(recipe.Sodium / servings),
+	}
 }
 
 type RecipeRequest struct {
-	Title       string                    `json:"title"`
-	CategoryID  string                    `json:"category_id"`
-	PrepTimeMin int                       `json:"prep_time_min"`
-	CookTimeMin int                       `json:"cook_time_min"`
-	Energy      int                       `json:"energy"`
-	Fat         float64                   `json:"fat"`
-	Protein     float64                   `json:"protein"`
-	Method      string                    `json:"method"`
-	ImageURL    string                    `json:"image_url"`
-	Ingredients []RecipeIngredientRequest `json:"ingredients"`
+	Title       string  `json:"title"`
+	CategoryID  string  `json:"category_id"` // устарело, используйте category_ids
+	PrepTimeMin int     `json:"prep_time_min"`
+	CookTimeMin int     `json:"cook_time_min"`
+	Energy      int     `json:"energy"`
+	Fat         float64 `json:"fat"`
+	Protein     float64 `json:"protein"`
+	Carbs       float64 `json:"carbs"`
+	Fiber       float64 `json:"fiber"`
+	Sugar       float64 `json:"sugar"`
+	Sodium      float64 `json:"sodium"`   // мг
+	Servings    int     `json:"servings"` // по умолчанию 1
+	// NutritionOverride сохраняет пищевую ценность как есть; иначе она рассчитывается по ингредиентам
+	NutritionOverride bool                      `json:"nutrition_override"`
+	Method            string                    `json:"method"`
+	ImageURL          string                    `json:"image_url"`
+	ImageFileID       string                    `json:"image_file_id"` // ID загруженного файла, image_url берётся из него
+	Ingredients       []RecipeIngredientRequest `json:"ingredients"`
+	// Steps заменяют method; если шагов нет, они получаются разбиением method по строкам
+	Steps []RecipeStepRequest `json:"steps"`
+	// DietTags — ручные метки (keto, halal и т.п.); vegan, vegetarian и *-free выводятся из ингредиентов
+	DietTags []string `json:"diet_tags"`
+	// CategoryIDs — все категории рецепта, первая становится основной
+	CategoryIDs []string `json:"category_ids"`
+	TagIDs      []string `json:"tag_ids"`
 }
 
 func NewRecipeResponseFromModel(recipe *model.RecipeCategoryIngredients) *RecipeResponse {
-	ingredients := make([]RecipeIngredientResponse, len(recipe.Ingredients))
-	for i, ing := range recipe.Ingredients {
-		ingredients[i] = RecipeIngredientResponse{
-			ID:     ing.ID,
-			Name:   ing.Name,
-			Amount: ing.Amount,
-			Unit:   ing.Unit,
-			Image:  ing.ImageURL,
-		}
-	}
+	ingredients := NewRecipeIngredientsFromModel(recipe.Ingredients)
 
 	category := NewCategoryFromModel(&recipe.Category)
 
+	categories := make([]Category, 0, len(recipe.Categories))
+	for _, c := range recipe.Categories {
+		categories = append(categories, *NewCategoryFromModel(&c))
+	}
+
+	tags := make([]Tag, 0, len(recipe.Tags))
+	for _, t := range recipe.Tags {
+		tags = append(tags, *NewTagFromModel(&t))
+	}
+
+	var highlight *RecipeHighlight
+	if recipe.Highlight != nil {
+		highlight = &RecipeHighlight{
+			Title:  recipe.Highlight.Title,
+			Method: recipe.Highlight.Method,
+		}
+	}
+
 	return &RecipeResponse{
-		ID:          recipe.Recipe.ID,
-		Title:       recipe.Recipe.Title,
-		PrepTimeMin: recipe.Recipe.PrepTimeMin,
-		CookTimeMin: recipe.Recipe.CookTimeMin,
-		Method:      recipe.Recipe.Method,
-		CreatedAt:   recipe.Recipe.CreatedAt,
-		ImageURL:    recipe.Recipe.ImageURL,
-		Energy:      recipe.Recipe.Energy,
-		Fat:         recipe.Recipe.Fat,
-		Protein:     recipe.Recipe.Protein,
-		Category:    category,
-		Ingredients: ingredients,
+		ID:                  recipe.Recipe.ID,
+		Title:               recipe.Recipe.Title,
+		PrepTimeMin:         recipe.Recipe.PrepTimeMin,
+		CookTimeMin:         recipe.Recipe.CookTimeMin,
+		Method:              recipe.Recipe.Method,
+		CreatedAt:           recipe.Recipe.CreatedAt,
+		ImageURL:            recipe.Recipe.ImageURL,
+		ImageFileID:         recipe.Recipe.ImageFileID,
+		Energy:              recipe.Recipe.Energy,
+		Fat:                 recipe.Recipe.Fat,
+		Protein:             recipe.Recipe.Protein,
+		Carbs:               recipe.Recipe.Carbs,
+		Fiber:               recipe.Recipe.Fiber,
+		Sugar:               recipe.Recipe.Sugar,
+		Sodium:              recipe.Recipe.Sodium,
+		Servings:            recipe.Recipe.Servings,
+		PerServing:          newPerServingNutrition(&recipe.Recipe),
+		NutritionOverride:   recipe.Recipe.NutritionOverride,
+		NutritionIncomplete: len(recipe.Recipe.NutritionSkipped) > 0,
+		NutritionSkipped:    append([]string{}, recipe.Recipe.NutritionSkipped...),
+		AuthorID:            recipe.Recipe.AuthorID,
+		AvgRating:           recipe.Recipe.AvgRating,
+		RatingCount:         recipe.Recipe.RatingCount,
+		Category:            category,
+		Categories:          categories,
+		Tags:                tags,
+		Ingredients:         ingredients,
+		Steps:               NewRecipeStepsFromModel(recipe.Steps),
+		IsFavorite:          recipe.IsFavorite,
+		Diet:                recipe.Diet,
+		Rank:                recipe.Rank,
+		Highlight:           highlight,
+	}
+}
+
+type RecipeMatchResponse struct {
+	Recipe       *RecipeResponse            `json:"recipe"`
+	MatchedCount int                        `json:"matched_count"`
+	TotalCount   int                        `json:"total_count"`
+	Coverage     float64                    `json:"coverage"`
+	Missing      []RecipeIngredientResponse `json:"missing"`
+	Substituted  []RecipeIngredientResponse `json:"substituted"`
+}
+
+func NewRecipeMatchResponseFromModel(match *model.RecipeMatch) *RecipeMatchResponse {
+	return &RecipeMatchResponse{
+		Recipe:       NewRecipeResponseFromModel(&match.RecipeCategoryIngredients),
+		MatchedCount: match.MatchedCount,
+		TotalCount:   match.TotalCount,
+		Coverage:     match.Coverage,
+		Missing:      NewRecipeIngredientsFromModel(match.Missing),
+		Substituted:  NewRecipeIngredientsFromModel(match.Substituted),
 	}
 }
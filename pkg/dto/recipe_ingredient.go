@@ -1,14 +1,31 @@
 package dto
 
+import "CookFinder.Backend/internal/model"
+
 type RecipeIngredientResponse struct {
-	ID     string `json:"id"` // ingredient_id
-	Name   string `json:"name"`
-	Amount int    `json:"amount"`
-	Unit   string `json:"unit"`
-	Image  string `json:"image_url"`
+	ID     string  `json:"id"` // ingredient_id
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
+	Image  string  `json:"image_url"`
 }
+
 type RecipeIngredientRequest struct {
-	ID     string `json:"id"` // ingredient_id
-	Amount int    `json:"amount"`
-	Unit   string `json:"unit"`
+	ID     string  `json:"id"` // ingredient_id
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
+}
+
+func NewRecipeIngredientsFromModel(ingredients []model.IngredientWithAmount) []RecipeIngredientResponse {
+	result := make([]RecipeIngredientResponse, len(ingredients))
+	for i, ing := range ingredients {
+		result[i] = RecipeIngredientResponse{
+			ID:     ing.ID,
+			Name:   ing.Name,
+			Amount: ing.Amount,
+			Unit:   ing.Unit,
+			Image:  ing.ImageURL,
+		}
+	}
+	return result
 }
@@ -0,0 +1,43 @@
+package dto
+
+import "CookFinder.Backend/internal/model"
+
+type RecipeStepRequest struct {
+	Text        string `json:"text"`
+	DurationSec int    `json:"duration_sec"`  // таймер шага, 0 если не нужен
+	ImageFileID string `json:"image_file_id"` // ID загруженного файла
+}
+
+type RecipeStepResponse struct {
+	Ordinal     int    `json:"ordinal"`
+	Text        string `json:"text"`
+	DurationSec int    `json:"duration_sec,omitempty"`
+	ImageFileID string `json:"image_file_id,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+}
+
+func NewRecipeStepsFromRequest(steps []RecipeStepRequest) []model.RecipeStep {
+	result := make([]model.RecipeStep, len(steps))
+	for i, step := range steps {
+		result[i] = model.RecipeStep{
+			Text:        step.Text,
+			DurationSec: step.DurationSec,
+			ImageFileID: step.ImageFileID,
+		}
+	}
+	return result
+}
+
+func NewRecipeStepsFromModel(steps []model.RecipeStep) []RecipeStepResponse {
+	result := make([]RecipeStepResponse, len(steps))
+	for i, step := range steps {
+		result[i] = RecipeStepResponse{
+			Ordinal:     step.Ordinal,
+			Text:        step.Text,
+			DurationSec: step.DurationSec,
+			ImageFileID: step.ImageFileID,
+			ImageURL:    step.ImageURL,
+		}
+	}
+	return result
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"CookFinder.Backend/internal/model"
+	"time"
+)
+
+type ReviewRequest struct {
+	Rating int    `json:"rating"`
+	Body   string `json:"body"`
+}
+
+type ReviewResponse struct {
+	RecipeID  string    `json:"recipe_id"`
+	UserID    string    `json:"user_id"`
+	Rating    int       `json:"rating"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewReviewFromModel(review *model.Review) *ReviewResponse {
+	return &ReviewResponse{
+		RecipeID:  review.RecipeID,
+		UserID:    review.UserID,
+		Rating:    review.Rating,
+		Body:      review.Body,
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+}
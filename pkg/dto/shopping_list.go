@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"CookFinder.Backend/internal/model"
+	"time"
+)
+
+type ShoppingListRecipeRequest struct {
+	RecipeID string `json:"recipe_id" binding:"required"`
+	Servings int    `json:"servings"` // 0 — на сколько порций написан рецепт
+}
+
+type ShoppingListRequest struct {
+	Name    string                      `json:"name"`
+	Recipes []ShoppingListRecipeRequest `json:"recipes" binding:"required,dive"`
+}
+
+type ShoppingListItemRequest struct {
+	Checked bool `json:"checked"`
+}
+
+type ShoppingListSummaryResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Shared    bool      `json:"shared"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewShoppingListSummaryFromModel(list *model.ShoppingList) *ShoppingListSummaryResponse {
+	return &ShoppingListSummaryResponse{
+		ID:        list.ID,
+		Name:      list.Name,
+		Shared:    list.ShareToken != "",
+		CreatedAt: list.CreatedAt,
+	}
+}
+
+type ShoppingListResponse struct {
+	ID         string                       `json:"id"`
+	Name       string                       `json:"name"`
+	ShareToken string                       `json:"share_token,omitempty"`
+	CreatedAt  time.Time                    `json:"created_at"`
+	Recipes    []ShoppingListRecipeResponse `json:"recipes"`
+	Aisles     []ShoppingListAisleResponse  `json:"aisles"` // в порядке обхода магазина
+}
+
+type ShoppingListRecipeResponse struct {
+	RecipeID string `json:"recipe_id"`
+	Title    string `json:"title"`
+	Servings int    `json:"servings"`
+}
+
+type ShoppingListAisleResponse struct {
+	Aisle string                     `json:"aisle"`
+	Items []ShoppingListItemResponse `json:"items"`
+}
+
+type ShoppingListItemResponse struct {
+	ID           string  `json:"id"`
+	IngredientID string  `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Amount       float64 `json:"amount"`
+	Unit         string  `json:"unit"`
+	Checked      bool    `json:"checked"`
+}
+
+// NewShoppingListFromModel группирует позиции по отделам; позиции уже отсортированы сервисом
+func NewShoppingListFromModel(list *model.ShoppingList) *ShoppingListResponse {
+	recipes := make([]ShoppingListRecipeResponse, 0, len(list.Recipes))
+	for _, r := range list.Recipes {
+		recipes = append(recipes, ShoppingListRecipeResponse(r))
+	}
+
+	aisles := make([]ShoppingListAisleResponse, 0)
+	for _, item := range list.Items {
+		if len(aisles) == 0 || aisles[len(aisles)-1].Aisle != item.Aisle {
+			aisles = append(aisles, ShoppingListAisleResponse{Aisle: item.Aisle, Items: make([]ShoppingListItemResponse, 0)})
+		}
+		last := &aisles[len(aisles)-1]
+		last.Items = append(last.Items, ShoppingListItemResponse{
+			ID:           item.ID,
+			IngredientID: item.IngredientID,
+			Name:         item.Name,
+			Amount:       item.Amount,
+			Unit:         item.Unit,
+			Checked:      item.Checked,
+		})
+	}
+
+	return &ShoppingListResponse{
+		ID:         list.ID,
+		Name:       list.Name,
+		ShareToken: list.ShareToken,
+		CreatedAt:  list.CreatedAt,
+		Recipes:    recipes,
+		Aisles:     aisles,
+	}
+}
+
+type ShareTokenResponse struct {
+	ShareToken string `json:"share_token"` // открывается через GET /shopping-lists/shared/{token}
+}
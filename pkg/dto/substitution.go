@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"CookFinder.Backend/internal/model"
+	"time"
+)
+
+type SubstitutionPartRequest struct {
+	IngredientID string  `json:"ingredient_id" binding:"required"`
+	Ratio        float64 `json:"ratio" binding:"required" example:"0.5"` // количество заменителя на единицу исходного ингредиента
+}
+
+type SubstitutionRequest struct {
+	IngredientID  string                    `json:"ingredient_id" binding:"required"`
+	Context       string                    `json:"context" enums:"any,baking,cooking,raw,sauce,drinks" example:"baking"`
+	Bidirectional bool                      `json:"bidirectional"` // только для замены одним ингредиентом
+	Notes         string                    `json:"notes"`
+	Parts         []SubstitutionPartRequest `json:"parts" binding:"required,dive"`
+}
+
+type SubstitutionPartResponse struct {
+	IngredientID string  `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Ratio        float64 `json:"ratio"`
+}
+
+type SubstitutionResponse struct {
+	ID             string                     `json:"id"`
+	IngredientID   string                     `json:"ingredient_id"`
+	IngredientName string                     `json:"ingredient_name"`
+	Context        string                     `json:"context"`
+	Bidirectional  bool                       `json:"bidirectional"`
+	Notes          string                     `json:"notes"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	Parts          []SubstitutionPartResponse `json:"parts"`
+}
+
+func NewSubstitutionResponseFromModel(sub *model.IngredientSubstitution) *SubstitutionResponse {
+	parts := make([]SubstitutionPartResponse, len(sub.Parts))
+	for i, part := range sub.Parts {
+		parts[i] = SubstitutionPartResponse{
+			IngredientID: part.IngredientID,
+			Name:         part.Name,
+			Ratio:        part.Ratio,
+		}
+	}
+
+	return &SubstitutionResponse{
+		ID:             sub.ID,
+		IngredientID:   sub.IngredientID,
+		IngredientName: sub.IngredientName,
+		Context:        sub.Context,
+		Bidirectional:  sub.Bidirectional,
+		Notes:          sub.Notes,
+		CreatedAt:      sub.CreatedAt,
+		Parts:          parts,
+	}
+}
+
+type RecipeSubstitutionsResponse struct {
+	Ingredient RecipeIngredientResponse     `json:"ingredient"`
+	Options    []SubstitutionOptionResponse `json:"options"` // сначала варианты, для которых всё есть
+}
+
+type SubstitutionOptionResponse struct {
+	SubstitutionID string                       `json:"substitution_id"`
+	Context        string                       `json:"context"`
+	Notes          string                       `json:"notes"`
+	Available      bool                         `json:"available"`
+	Parts          []SubstitutionAmountResponse `json:"parts"`
+}
+
+type SubstitutionAmountResponse struct {
+	IngredientID string  `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Amount       float64 `json:"amount"`
+	Unit         string  `json:"unit"`
+	InPantry     bool    `json:"in_pantry"`
+}
+
+func NewRecipeSubstitutionsFromModel(subs []model.RecipeSubstitutions) []RecipeSubstitutionsResponse {
+	result := make([]RecipeSubstitutionsResponse, len(subs))
+	for i, sub := range subs {
+		options := make([]SubstitutionOptionResponse, len(sub.Options))
+		for j, option := range sub.Options {
+			parts := make([]SubstitutionAmountResponse, len(option.Parts))
+			for k, part := range option.Parts {
+				parts[k] = SubstitutionAmountResponse{
+					IngredientID: part.IngredientID,
+					Name:         part.Name,
+					Amount:       part.Amount,
+					Unit:         part.Unit,
+					InPantry:     part.InPantry,
+				}
+			}
+			options[j] = SubstitutionOptionResponse{
+				SubstitutionID: option.SubstitutionID,
+				Context:        option.Context,
+				Notes:          option.Notes,
+				Available:      option.Available,
+				Parts:          parts,
+			}
+		}
+
+		result[i] = RecipeSubstitutionsResponse{
+			Ingredient: NewRecipeIngredientsFromModel([]model.IngredientWithAmount{sub.Ingredient})[0],
+			Options:    options,
+		}
+	}
+	return result
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "CookFinder.Backend/internal/model"
+
+type Tag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Kind string `json:"kind" enums:"cuisine,meal_type,occasion,other" example:"cuisine"`
+}
+
+func NewTagFromModel(tag *model.Tag) *Tag {
+	return &Tag{
+		ID:   tag.ID,
+		Name: tag.Name,
+		Kind: tag.Kind,
+	}
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "CookFinder.Backend/internal/model"
+
+type UnitResponse struct {
+	Code      string   `json:"code"`
+	Name      string   `json:"name"`
+	Dimension string   `json:"dimension"` // mass, volume, count
+	System    string   `json:"system"`    // metric, imperial, any
+	ToBase    float64  `json:"to_base"`   // сколько g, ml или pcs в одной единице
+	Aliases   []string `json:"aliases"`
+}
+
+type ConversionResponse struct {
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
+}
+
+func NewUnitFromModel(unit *model.Unit) *UnitResponse {
+	return &UnitResponse{
+		Code:      unit.Code,
+		Name:      unit.Name,
+		Dimension: unit.Dimension,
+		System:    unit.System,
+		ToBase:    unit.ToBase,
+		Aliases:   nonNilStrings(unit.Aliases),
+	}
+}
@@ -27,9 +27,9 @@ var (
 	ErrResourceNotFound = NewPubErr("resource not found").SetCode(16).SetHTTPCode(http.StatusNotFound)
 	ErrInvalidParams    = NewPubErr("invalid params").SetCode(17)
 	ErrInternal         = NewPubErr("internal error").SetCode(18).SetHTTPCode(http.StatusInternalServerError)
-	ErrNotOwnedResource = NewPubErr("this resource is not owned by this author").SetCode(19)
+	ErrNotOwnedResource = NewPubErr("this resource is not owned by this author").SetCode(19).SetHTTPCode(http.StatusForbidden)
 	ErrInvalidRequest   = NewPubErr("request format is not valid").SetCode(20)
-	ErrInvalidToken     = NewPubErr("invalid token").SetCode(21)
+	ErrInvalidToken     = NewPubErr("invalid token").SetCode(21).SetHTTPCode(http.StatusUnauthorized)
 )
 
 var CodeToErr = map[int]PubErr{